@@ -0,0 +1,8 @@
+#!/bin/sh
+# Regenerates the CRDs in deploy/crds from the API types. See postprocess-crds.py for what is changed in the output of
+# operator-sdk, and why.
+set -e
+cd "$(dirname "$0")/.."
+
+operator-sdk generate crds
+python3 build/postprocess-crds.py deploy/crds/*_crd.yaml
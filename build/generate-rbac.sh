@@ -0,0 +1,37 @@
+#!/bin/sh
+# Generates the RBAC manifests for an Operator watching a set of namespaces rather than the whole cluster. They
+# replace deploy/role_binding.yaml, binding deploy/role.yaml's ClusterRole in the watched namespaces only.
+#
+# Usage: generate-rbac.sh <operator-namespace> <namespace>[,<namespace>...]
+#
+# An Operator watching namespaces chosen by --watch-namespace-selector watches the whole cluster, and filters
+# namespaces itself: it needs deploy/role_binding.yaml's ClusterRoleBinding instead.
+set -e
+
+if [ $# -ne 2 ]; then
+    echo "Usage: $0 <operator-namespace> <namespace>[,<namespace>...]" >&2
+    exit 1
+fi
+
+OPERATOR_NAMESPACE=$1
+NAMESPACES=$(echo "$2" | tr ',' ' ')
+
+# The Operator's own namespace holds the leader lock and the metrics Service
+for NAMESPACE in $(echo "${OPERATOR_NAMESPACE} ${NAMESPACES}" | tr ' ' '\n' | sort -u); do
+    cat <<YAML
+---
+kind: RoleBinding
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: astarte-operator
+  namespace: ${NAMESPACE}
+subjects:
+- kind: ServiceAccount
+  name: astarte-operator
+  namespace: ${OPERATOR_NAMESPACE}
+roleRef:
+  kind: ClusterRole
+  name: astarte-operator
+  apiGroup: rbac.authorization.k8s.io
+YAML
+done
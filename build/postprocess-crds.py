@@ -0,0 +1,97 @@
+#!/usr/bin/env python3
+"""Post-processes the CRDs generated by operator-sdk in deploy/crds.
+
+The Astarte CRD embeds several Kubernetes types, such as Containers, Volumes and Affinities, in every Component.
+Their full schemas would make the CRD far larger than the 262144 bytes kubectl apply can store in the
+last-applied-configuration annotation. They are replaced by objects preserving unknown fields, keeping their
+description: the API Server validates their content anyway when the Pods are created.
+
+Both CRDs are also set up to convert between v1alpha1 and v1alpha2 through the Operator's webhook.
+
+Usage: postprocess-crds.py <crd.yaml>...
+"""
+import re
+import sys
+
+# Properties holding embedded Kubernetes types, by the JSON type of the property itself
+SCHEMALESS_ARRAYS = {"initContainers", "sidecars", "additionalVolumes", "additionalEnv", "topologySpreadConstraints"}
+SCHEMALESS_OBJECTS = {"customAffinity", "volumeDefinition", "podSecurityContext", "securityContext"}
+
+CONVERSION = """  conversion:
+    strategy: Webhook
+    webhookClientConfig:
+      service:
+        name: astarte-operator-webhook
+        namespace: kube-system
+        path: /convert
+      # Replace this with the base64 encoded CA which signed the Operator's serving certificate
+      caBundle: Cg==
+"""
+
+PROPERTY = re.compile(r"^( *)([A-Za-z]+):$")
+
+
+def indentation(line):
+    return len(line) - len(line.lstrip(" "))
+
+
+def get_description(subtree, indent):
+    """Returns the lines of the description of a property, whose children are indented by indent"""
+    description = []
+    for line in subtree:
+        if description and indentation(line) <= indent:
+            break
+        if description or (indentation(line) == indent and line.lstrip().startswith("description:")):
+            description.append(line)
+    return description
+
+
+def prune_schemas(lines):
+    out = []
+    i = 0
+    while i < len(lines):
+        line = lines[i]
+        match = PROPERTY.match(line)
+        if not match or match.group(2) not in SCHEMALESS_ARRAYS | SCHEMALESS_OBJECTS:
+            out.append(line)
+            i += 1
+            continue
+
+        indent = len(match.group(1))
+        end = i + 1
+        while end < len(lines) and indentation(lines[end]) > indent:
+            end += 1
+        child = " " * (indent + 2)
+        out.append(line)
+        out.extend(get_description(lines[i + 1:end], indent + 2))
+        if match.group(2) in SCHEMALESS_ARRAYS:
+            out.append(child + "items:\n")
+            out.append(child + "  type: object\n")
+            out.append(child + "  x-kubernetes-preserve-unknown-fields: true\n")
+            out.append(child + "type: array\n")
+        else:
+            out.append(child + "type: object\n")
+            out.append(child + "x-kubernetes-preserve-unknown-fields: true\n")
+        i = end
+    return out
+
+
+def add_conversion(content):
+    if "\n  conversion:\n" not in content:
+        content = content.replace("\nspec:\n", "\nspec:\n" + CONVERSION, 1)
+    if "\n  preserveUnknownFields: false\n" not in content:
+        content = content.replace("\n  scope: Namespaced\n", "\n  preserveUnknownFields: false\n  scope: Namespaced\n", 1)
+    return content
+
+
+def main():
+    for path in sys.argv[1:]:
+        with open(path) as f:
+            lines = f.readlines()
+        content = add_conversion("".join(prune_schemas(lines)))
+        with open(path, "w") as f:
+            f.write(content)
+
+
+if __name__ == "__main__":
+    main()
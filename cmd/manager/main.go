@@ -13,7 +13,10 @@ import (
 	"k8s.io/client-go/rest"
 
 	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis"
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
 	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/controller"
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/webhook"
 	"github.com/astarte-platform/astarte-kubernetes-operator/version"
 
 	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
@@ -24,6 +27,7 @@ import (
 	sdkVersion "github.com/operator-framework/operator-sdk/version"
 	"github.com/spf13/pflag"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -36,6 +40,7 @@ var (
 	metricsHost               = "0.0.0.0"
 	metricsPort         int32 = 8383
 	operatorMetricsPort int32 = 8686
+	webhookPort               = 9443
 )
 var log = logf.Log.WithName("cmd")
 
@@ -50,11 +55,20 @@ func main() {
 	// Add the zap logger flag set to the CLI. The flag set must
 	// be added before calling pflag.Parse().
 	pflag.CommandLine.AddFlagSet(zap.FlagSet())
+	// Add the flags configuring how long the Operator waits for the cluster
+	pflag.CommandLine.AddFlagSet(misc.TimeoutsFlagSet())
+	// Add the flags choosing the watched namespaces
+	pflag.CommandLine.AddFlagSet(misc.NamespacesFlagSet())
 
 	// Add flags registered by imported packages (e.g. glog and
 	// controller-runtime)
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
 
+	enableWebhooks := pflag.Bool("enable-webhooks", false,
+		"Serve the Astarte Admission Webhooks. Requires a serving certificate and key in --webhook-cert-dir")
+	webhookCertDir := pflag.String("webhook-cert-dir", "/tmp/k8s-webhook-server/serving-certs",
+		"Directory containing tls.crt and tls.key for serving the Admission Webhooks")
+
 	pflag.Parse()
 
 	// Use a zap logr.Logger implementation. If none of the zap
@@ -69,18 +83,26 @@ func main() {
 
 	printVersion()
 
-	namespace, err := k8sutil.GetWatchNamespace()
+	// Get a config to talk to the apiserver
+	cfg, err := config.GetConfig()
 	if err != nil {
-		log.Error(err, "Failed to get watch namespace")
+		log.Error(err, "")
 		os.Exit(1)
 	}
 
-	// Get a config to talk to the apiserver
-	cfg, err := config.GetConfig()
+	namespaces, err := getWatchNamespaces()
 	if err != nil {
-		log.Error(err, "")
+		log.Error(err, "Failed to get watch namespaces")
 		os.Exit(1)
 	}
+	if misc.IsWatchNamespaceSelectorSet() {
+		selector, _ := misc.GetWatchNamespaceSelector()
+		log.Info("Watching namespaces matching selector", "Selector", selector.String())
+	} else if len(namespaces) == 0 {
+		log.Info("Watching all namespaces")
+	} else {
+		log.Info("Watching namespaces", "Namespaces", namespaces)
+	}
 
 	ctx := context.TODO()
 	// Become the leader before proceeding
@@ -91,10 +113,13 @@ func main() {
 	}
 
 	// Create a new Cmd to provide shared dependencies and start components
-	mgr, err := manager.New(cfg, manager.Options{
-		Namespace:          namespace,
+	options := manager.Options{
 		MetricsBindAddress: fmt.Sprintf("%s:%d", metricsHost, metricsPort),
-	})
+		Port:               webhookPort,
+		CertDir:            *webhookCertDir,
+	}
+	setManagerNamespaces(&options, namespaces)
+	mgr, err := manager.New(cfg, options)
 	if err != nil {
 		log.Error(err, "")
 		os.Exit(1)
@@ -120,7 +145,15 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err = serveCRMetrics(cfg); err != nil {
+	// Setup all Webhooks, if requested
+	if *enableWebhooks {
+		if err := webhook.AddToManager(mgr); err != nil {
+			log.Error(err, "")
+			os.Exit(1)
+		}
+	}
+
+	if err = serveCRMetrics(cfg, namespaces); err != nil {
 		log.Info("Could not generate and serve custom resource metrics", "error", err.Error())
 	}
 
@@ -138,7 +171,10 @@ func main() {
 	// CreateServiceMonitors will automatically create the prometheus-operator ServiceMonitor resources
 	// necessary to configure Prometheus to scrape metrics from this operator.
 	services := []*v1.Service{service}
-	_, err = metrics.CreateServiceMonitors(cfg, namespace, services)
+	operatorNs, err := k8sutil.GetOperatorNamespace()
+	if err == nil {
+		_, err = metrics.CreateServiceMonitors(cfg, operatorNs, services)
+	}
 	if err != nil {
 		log.Info("Could not create ServiceMonitor object", "error", err.Error())
 		// If this operator is deployed to a cluster without the prometheus-operator running, it will return
@@ -157,22 +193,27 @@ func main() {
 	}
 }
 
-// serveCRMetrics gets the Operator/CustomResource GVKs and generates metrics based on those types.
-// It serves those metrics on "http://metricsHost:operatorMetricsPort".
-func serveCRMetrics(cfg *rest.Config) error {
+// serveCRMetrics gets the Operator/CustomResource GVKs and generates metrics based on those types, for every watched
+// namespace. It serves those metrics on "http://metricsHost:operatorMetricsPort".
+func serveCRMetrics(cfg *rest.Config, namespaces []string) error {
 	// Below function returns filtered operator/CustomResource specific GVKs.
 	// For more control override the below GVK list with your own custom logic.
-	filteredGVK, err := k8sutil.GetGVKsFromAddToScheme(apis.AddToScheme)
+	gvks, err := k8sutil.GetGVKsFromAddToScheme(apis.AddToScheme)
 	if err != nil {
 		return err
 	}
-	// Get the namespace the operator is currently deployed in.
-	operatorNs, err := k8sutil.GetOperatorNamespace()
-	if err != nil {
-		return err
+	// Every version of a resource lists the same objects: only the storage version is exposed, not to count them twice
+	filteredGVK := gvks[:0]
+	for _, gvk := range gvks {
+		if gvk.GroupVersion() == apiv1alpha2.SchemeGroupVersion {
+			filteredGVK = append(filteredGVK, gvk)
+		}
+	}
+	// Resources in all namespaces are covered when watching the whole cluster
+	ns := namespaces
+	if len(ns) == 0 {
+		ns = []string{metav1.NamespaceAll}
 	}
-	// To generate metrics in other namespaces, add the values below.
-	ns := []string{operatorNs}
 	// Generate and serve custom resource specific metrics.
 	err = kubemetrics.GenerateAndServeCRMetrics(cfg, ns, filteredGVK, metricsHost, operatorMetricsPort)
 	if err != nil {
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
+	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// getWatchNamespaces returns the namespaces listed in WATCH_NAMESPACE, separated by commas. An empty list means the
+// whole cluster is watched, which is the case when namespaces are chosen by a label selector: the Controllers filter
+// their events by namespace instead, so that namespaces labelled or created later are picked up.
+func getWatchNamespaces() ([]string, error) {
+	watchNamespace, err := k8sutil.GetWatchNamespace()
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := []string{}
+	for _, ns := range strings.Split(watchNamespace, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	if !misc.IsWatchNamespaceSelectorSet() {
+		return namespaces, nil
+	}
+	if len(namespaces) > 0 {
+		return nil, fmt.Errorf("%s and a namespace selector can't be used together: set %s to an empty string",
+			k8sutil.WatchNamespaceEnvVar, k8sutil.WatchNamespaceEnvVar)
+	}
+	if _, err := misc.GetWatchNamespaceSelector(); err != nil {
+		return nil, err
+	}
+
+	return namespaces, nil
+}
+
+// setManagerNamespaces restricts the Manager's cache to namespaces, unless it's empty
+func setManagerNamespaces(options *manager.Options, namespaces []string) {
+	switch len(namespaces) {
+	case 0:
+		// Watch the whole cluster
+	case 1:
+		options.Namespace = namespaces[0]
+	default:
+		options.NewCache = cache.MultiNamespacedCacheBuilder(namespaces)
+	}
+}
@@ -8,8 +8,10 @@ require (
 	github.com/openlyinc/pointy v1.1.2
 	github.com/operator-framework/operator-sdk v0.14.0
 	github.com/pkg/errors v0.8.1
+	github.com/prometheus/client_golang v1.2.1
 	github.com/spf13/pflag v1.0.5
 	golang.org/x/sys v0.0.0-20191224085550-c709ea063b76 // indirect
+	gomodules.xyz/jsonpatch/v2 v2.0.1
 	gopkg.in/yaml.v2 v2.2.7 // indirect
 	k8s.io/api v0.0.0
 	k8s.io/apiextensions-apiserver v0.0.0
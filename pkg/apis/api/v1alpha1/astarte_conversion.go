@@ -0,0 +1,339 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	"github.com/openlyinc/pointy"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this Astarte to the Hub version (v1alpha2)
+func (src *Astarte) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha2.Astarte)
+	if !ok {
+		return fmt.Errorf("Expected a v1alpha2 Astarte, got %T", dstRaw)
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v1alpha2.AstarteSpec{
+		Version:             src.Spec.Version,
+		ImagePullPolicy:     src.Spec.ImagePullPolicy,
+		ImagePullSecrets:    src.Spec.ImagePullSecrets,
+		DistributionChannel: src.Spec.DistributionChannel,
+		DeploymentStrategy:  src.Spec.DeploymentStrategy,
+		RBAC:                src.Spec.RBAC,
+		StorageClassName:    src.Spec.StorageClassName,
+		API:                 v1alpha2.AstarteAPISpec(src.Spec.API),
+		RabbitMQ: v1alpha2.AstarteRabbitMQSpec{
+			AstarteGenericClusteredResource: convertGenericClusteredResourceTo(src.Spec.RabbitMQ.GenericClusteredResource),
+			Connection:                      convertRabbitMQConnectionTo(src.Spec.RabbitMQ.Connection),
+			Storage:                         (*v1alpha2.AstartePersistentStorageSpec)(src.Spec.RabbitMQ.Storage),
+			AdditionalPlugins:               src.Spec.RabbitMQ.AdditionalPlugins,
+		},
+		Cassandra: v1alpha2.AstarteCassandraSpec{
+			AstarteGenericClusteredResource: convertGenericClusteredResourceTo(src.Spec.Cassandra.GenericClusteredResource),
+			Nodes:                           src.Spec.Cassandra.Nodes,
+			MaxHeapSize:                     src.Spec.Cassandra.MaxHeapSize,
+			HeapNewSize:                     src.Spec.Cassandra.HeapNewSize,
+			Storage:                         (*v1alpha2.AstartePersistentStorageSpec)(src.Spec.Cassandra.Storage),
+		},
+		VerneMQ: v1alpha2.AstarteVerneMQSpec{
+			AstarteGenericClusteredResource: convertGenericClusteredResourceTo(src.Spec.VerneMQ.GenericClusteredResource),
+			Host:                            src.Spec.VerneMQ.Host,
+			Port:                            src.Spec.VerneMQ.Port,
+			CaSecret:                        src.Spec.VerneMQ.CaSecret,
+			Storage:                         (*v1alpha2.AstartePersistentStorageSpec)(src.Spec.VerneMQ.Storage),
+		},
+		CFSSL: convertCFSSLTo(src.Spec.CFSSL),
+		Components: v1alpha2.AstarteComponentsSpec{
+			Resources:       src.Spec.Components.Resources,
+			Housekeeping:    convertGenericComponentTo(src.Spec.Components.Housekeeping),
+			RealmManagement: convertGenericComponentTo(src.Spec.Components.RealmManagement),
+			Pairing:         convertGenericComponentTo(src.Spec.Components.Pairing),
+			DataUpdaterPlant: v1alpha2.AstarteDataUpdaterPlantSpec{
+				AstarteGenericClusteredResource: convertGenericClusteredResourceTo(src.Spec.Components.DataUpdaterPlant.GenericClusteredResource),
+				DataQueueCount:                  src.Spec.Components.DataUpdaterPlant.DataQueueCount,
+			},
+			AppengineAPI: v1alpha2.AstarteAppengineAPISpec{
+				AstarteGenericAPISpec: convertGenericAPITo(src.Spec.Components.AppengineAPI.GenericAPISpec),
+				MaxResultsLimit:       src.Spec.Components.AppengineAPI.MaxResultsLimit,
+			},
+			TriggerEngine: convertGenericClusteredResourceTo(src.Spec.Components.TriggerEngine),
+			Dashboard:     convertDashboardTo(src.Spec.Components.Dashboard),
+		},
+	}
+	dst.Status = v1alpha2.AstarteStatus{
+		ReconciliationPhase: v1alpha2.ReconciliationPhase(src.Status.ReconciliationPhase),
+		AstarteVersion:      src.Status.AstarteVersion,
+		OperatorVersion:     src.Status.OperatorVersion,
+		Health:              src.Status.Health,
+		BaseAPIURL:          src.Status.BaseAPIURL,
+		BrokerURL:           src.Status.BrokerURL,
+	}
+
+	return restoreAstarteHubFields(dst)
+}
+
+// ConvertFrom converts from the Hub version (v1alpha2) to this version. Fields which can't be represented in
+// v1alpha1 are stored in the HubFieldsAnnotation, and restored by ConvertTo.
+func (dst *Astarte) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha2.Astarte)
+	if !ok {
+		return fmt.Errorf("Expected a v1alpha2 Astarte, got %T", srcRaw)
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = AstarteSpec{
+		Version:             src.Spec.Version,
+		ImagePullPolicy:     src.Spec.ImagePullPolicy,
+		ImagePullSecrets:    src.Spec.ImagePullSecrets,
+		DistributionChannel: src.Spec.DistributionChannel,
+		DeploymentStrategy:  src.Spec.DeploymentStrategy,
+		RBAC:                src.Spec.RBAC,
+		StorageClassName:    src.Spec.StorageClassName,
+		API:                 AstarteAPISpec(src.Spec.API),
+		RabbitMQ: AstarteRabbitMQSpec{
+			GenericClusteredResource: convertGenericClusteredResourceFrom(src.Spec.RabbitMQ.AstarteGenericClusteredResource),
+			Connection:               convertRabbitMQConnectionFrom(src.Spec.RabbitMQ.Connection),
+			Storage:                  (*AstartePersistentStorageSpec)(src.Spec.RabbitMQ.Storage),
+			AdditionalPlugins:        src.Spec.RabbitMQ.AdditionalPlugins,
+		},
+		Cassandra: AstarteCassandraSpec{
+			GenericClusteredResource: convertGenericClusteredResourceFrom(src.Spec.Cassandra.AstarteGenericClusteredResource),
+			Nodes:                    src.Spec.Cassandra.Nodes,
+			MaxHeapSize:              src.Spec.Cassandra.MaxHeapSize,
+			HeapNewSize:              src.Spec.Cassandra.HeapNewSize,
+			Storage:                  (*AstartePersistentStorageSpec)(src.Spec.Cassandra.Storage),
+		},
+		VerneMQ: AstarteVerneMQSpec{
+			GenericClusteredResource: convertGenericClusteredResourceFrom(src.Spec.VerneMQ.AstarteGenericClusteredResource),
+			Host:                     src.Spec.VerneMQ.Host,
+			Port:                     src.Spec.VerneMQ.Port,
+			CaSecret:                 src.Spec.VerneMQ.CaSecret,
+			Storage:                  (*AstartePersistentStorageSpec)(src.Spec.VerneMQ.Storage),
+		},
+		CFSSL: convertCFSSLFrom(src.Spec.CFSSL),
+		Components: AstarteComponentsSpec{
+			Resources:       src.Spec.Components.Resources,
+			Housekeeping:    convertGenericComponentFrom(src.Spec.Components.Housekeeping),
+			RealmManagement: convertGenericComponentFrom(src.Spec.Components.RealmManagement),
+			Pairing:         convertGenericComponentFrom(src.Spec.Components.Pairing),
+			DataUpdaterPlant: AstarteDataUpdaterPlantSpec{
+				GenericClusteredResource: convertGenericClusteredResourceFrom(src.Spec.Components.DataUpdaterPlant.AstarteGenericClusteredResource),
+				DataQueueCount:           src.Spec.Components.DataUpdaterPlant.DataQueueCount,
+			},
+			AppengineAPI: AstarteAppengineAPISpec{
+				GenericAPISpec:  convertGenericAPIFrom(src.Spec.Components.AppengineAPI.AstarteGenericAPISpec),
+				MaxResultsLimit: src.Spec.Components.AppengineAPI.MaxResultsLimit,
+			},
+			TriggerEngine: convertGenericClusteredResourceFrom(src.Spec.Components.TriggerEngine),
+			Dashboard:     convertDashboardFrom(src.Spec.Components.Dashboard),
+		},
+	}
+	dst.Status = AstarteStatus{
+		ReconciliationPhase: ReconciliationPhase(src.Status.ReconciliationPhase),
+		AstarteVersion:      src.Status.AstarteVersion,
+		OperatorVersion:     src.Status.OperatorVersion,
+		Health:              src.Status.Health,
+		BaseAPIURL:          src.Status.BaseAPIURL,
+		BrokerURL:           src.Status.BrokerURL,
+	}
+
+	return storeAstarteHubFields(src, dst)
+}
+
+func convertRabbitMQConnectionTo(in *AstarteRabbitMQConnectionSpec) *v1alpha2.AstarteRabbitMQConnectionSpec {
+	if in == nil {
+		return nil
+	}
+	return &v1alpha2.AstarteRabbitMQConnectionSpec{
+		Host:     in.Host,
+		Port:     in.Port,
+		Username: in.Username,
+		Password: in.Password,
+		Secret:   (*v1alpha2.AstarteRabbitMQConnectionSecretSpec)(in.Secret),
+	}
+}
+
+func convertRabbitMQConnectionFrom(in *v1alpha2.AstarteRabbitMQConnectionSpec) *AstarteRabbitMQConnectionSpec {
+	if in == nil {
+		return nil
+	}
+	return &AstarteRabbitMQConnectionSpec{
+		Host:     in.Host,
+		Port:     in.Port,
+		Username: in.Username,
+		Password: in.Password,
+		Secret:   (*AstarteRabbitMQConnectionSecretSpec)(in.Secret),
+	}
+}
+
+func convertGenericClusteredResourceTo(in AstarteGenericClusteredResource) v1alpha2.AstarteGenericClusteredResource {
+	return v1alpha2.AstarteGenericClusteredResource{
+		Deploy:         in.Deploy,
+		Replicas:       in.Replicas,
+		AntiAffinity:   in.AntiAffinity,
+		CustomAffinity: in.CustomAffinity,
+		Version:        in.Version,
+		Image:          in.Image,
+		Resources:      in.Resources,
+	}
+}
+
+func convertGenericClusteredResourceFrom(in v1alpha2.AstarteGenericClusteredResource) AstarteGenericClusteredResource {
+	out := AstarteGenericClusteredResource{
+		Deploy:         in.Deploy,
+		Replicas:       in.Replicas,
+		AntiAffinity:   in.AntiAffinity,
+		CustomAffinity: in.CustomAffinity,
+		Version:        in.Version,
+		Image:          in.Image,
+		Resources:      in.Resources,
+	}
+	// v1alpha1 can only tell whether Pods have any anti affinity at all
+	if in.AntiAffinityMode != "" {
+		out.AntiAffinity = pointy.Bool(in.AntiAffinityMode != v1alpha2.AntiAffinityNone)
+	}
+	return out
+}
+
+func convertGenericAPITo(in AstarteGenericAPISpec) v1alpha2.AstarteGenericAPISpec {
+	return v1alpha2.AstarteGenericAPISpec{
+		AstarteGenericClusteredResource: convertGenericClusteredResourceTo(in.GenericClusteredResource),
+		DisableAuthentication:           in.DisableAuthentication,
+	}
+}
+
+func convertGenericAPIFrom(in v1alpha2.AstarteGenericAPISpec) AstarteGenericAPISpec {
+	return AstarteGenericAPISpec{
+		GenericClusteredResource: convertGenericClusteredResourceFrom(in.AstarteGenericClusteredResource),
+		DisableAuthentication:    in.DisableAuthentication,
+	}
+}
+
+func convertGenericComponentTo(in AstarteGenericComponentSpec) v1alpha2.AstarteGenericComponentSpec {
+	return v1alpha2.AstarteGenericComponentSpec{
+		API:     convertGenericAPITo(in.API),
+		Backend: convertGenericClusteredResourceTo(in.Backend),
+	}
+}
+
+func convertGenericComponentFrom(in v1alpha2.AstarteGenericComponentSpec) AstarteGenericComponentSpec {
+	return AstarteGenericComponentSpec{
+		API:     convertGenericAPIFrom(in.API),
+		Backend: convertGenericClusteredResourceFrom(in.Backend),
+	}
+}
+
+func convertDashboardTo(in AstarteDashboardSpec) v1alpha2.AstarteDashboardSpec {
+	out := v1alpha2.AstarteDashboardSpec{
+		AstarteGenericClusteredResource: convertGenericClusteredResourceTo(in.GenericClusteredResource),
+		SSL:                             in.SSL,
+		Host:                            in.Host,
+		Config: v1alpha2.AstarteDashboardConfigSpec{
+			RealmManagementAPIURL: in.Config.RealmManagementAPIURL,
+			DefaultRealm:          in.Config.DefaultRealm,
+			DefaultAuth:           in.Config.DefaultAuth,
+		},
+	}
+	for _, auth := range in.Config.Auth {
+		out.Config.Auth = append(out.Config.Auth, v1alpha2.AstarteDashboardConfigAuthSpec(auth))
+	}
+	return out
+}
+
+func convertDashboardFrom(in v1alpha2.AstarteDashboardSpec) AstarteDashboardSpec {
+	out := AstarteDashboardSpec{
+		GenericClusteredResource: convertGenericClusteredResourceFrom(in.AstarteGenericClusteredResource),
+		SSL:                      in.SSL,
+		Host:                     in.Host,
+		Config: AstarteDashboardConfigSpec{
+			RealmManagementAPIURL: in.Config.RealmManagementAPIURL,
+			DefaultRealm:          in.Config.DefaultRealm,
+			DefaultAuth:           in.Config.DefaultAuth,
+		},
+	}
+	for _, auth := range in.Config.Auth {
+		out.Config.Auth = append(out.Config.Auth, AstarteDashboardConfigAuthSpec(auth))
+	}
+	return out
+}
+
+func convertCFSSLTo(in AstarteCFSSLSpec) v1alpha2.AstarteCFSSLSpec {
+	out := v1alpha2.AstarteCFSSLSpec{
+		Deploy:            in.Deploy,
+		URL:               in.URL,
+		CaExpiry:          in.CaExpiry,
+		CertificateExpiry: in.CertificateExpiry,
+		DBConfig:          (*v1alpha2.AstarteCFSSLDBConfigSpec)(in.DBConfig),
+		Resources:         in.Resources,
+		Version:           in.Version,
+		Image:             in.Image,
+		Storage:           (*v1alpha2.AstartePersistentStorageSpec)(in.Storage),
+	}
+	if in.CSRRootCa != nil {
+		out.CSRRootCa = &v1alpha2.AstarteCFSSLCSRRootCASpec{
+			CN:  in.CSRRootCa.CN,
+			Key: (*v1alpha2.AstarteCFSSLCSRRootCAKeySpec)(in.CSRRootCa.Key),
+			CA:  (*v1alpha2.AstarteCFSSLCSRRootCACASpec)(in.CSRRootCa.CA),
+		}
+		for _, name := range in.CSRRootCa.Names {
+			out.CSRRootCa.Names = append(out.CSRRootCa.Names, v1alpha2.AstarteCFSSLCSRRootCANamesSpec(name))
+		}
+	}
+	if in.CARootConfig != nil {
+		out.CARootConfig = &v1alpha2.AstarteCFSSLCARootConfigSpec{}
+		if in.CARootConfig.Signing != nil {
+			out.CARootConfig.Signing = &v1alpha2.AstarteCFSSLCARootConfigSigningSpec{}
+			if in.CARootConfig.Signing.Default != nil {
+				out.CARootConfig.Signing.Default = &v1alpha2.AstarteCFSSLCARootConfigSigningDefaultSpec{
+					Usages: in.CARootConfig.Signing.Default.Usages,
+					Expiry: in.CARootConfig.Signing.Default.Expiry,
+					CAConstraint: (*v1alpha2.AstarteCFSSLCARootConfigSigningCAConstraintSpec)(
+						in.CARootConfig.Signing.Default.CAConstraint),
+				}
+			}
+		}
+	}
+	return out
+}
+
+func convertCFSSLFrom(in v1alpha2.AstarteCFSSLSpec) AstarteCFSSLSpec {
+	out := AstarteCFSSLSpec{
+		Deploy:            in.Deploy,
+		URL:               in.URL,
+		CaExpiry:          in.CaExpiry,
+		CertificateExpiry: in.CertificateExpiry,
+		DBConfig:          (*AstarteCFSSLDBConfigSpec)(in.DBConfig),
+		Resources:         in.Resources,
+		Version:           in.Version,
+		Image:             in.Image,
+		Storage:           (*AstartePersistentStorageSpec)(in.Storage),
+	}
+	if in.CSRRootCa != nil {
+		out.CSRRootCa = &AstarteCFSSLCSRRootCASpec{
+			CN:  in.CSRRootCa.CN,
+			Key: (*AstarteCFSSLCSRRootCAKeySpec)(in.CSRRootCa.Key),
+			CA:  (*AstarteCFSSLCSRRootCACASpec)(in.CSRRootCa.CA),
+		}
+		for _, name := range in.CSRRootCa.Names {
+			out.CSRRootCa.Names = append(out.CSRRootCa.Names, AstarteCFSSLCSRRootCANamesSpec(name))
+		}
+	}
+	if in.CARootConfig != nil {
+		out.CARootConfig = &AstarteCFSSLCARootConfigSpec{}
+		if in.CARootConfig.Signing != nil {
+			out.CARootConfig.Signing = &AstarteCFSSLCARootConfigSigningSpec{}
+			if in.CARootConfig.Signing.Default != nil {
+				out.CARootConfig.Signing.Default = &AstarteCFSSLCARootConfigSigningDefaultSpec{
+					Usages: in.CARootConfig.Signing.Default.Usages,
+					Expiry: in.CARootConfig.Signing.Default.Expiry,
+					CAConstraint: (*AstarteCFSSLCARootConfigSigningCAConstraintSpec)(
+						in.CARootConfig.Signing.Default.CAConstraint),
+				}
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,217 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	"github.com/openlyinc/pointy"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/diff"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Timestamps are encoded with a precision of one second, and decoded in the local time zone
+var testTime = metav1.NewTime(time.Unix(1600000000, 0))
+
+// Quantities are compared semantically, as decoding them doesn't restore their cached encoding
+func assertSemanticEqual(t *testing.T, what string, expected, actual interface{}) {
+	t.Helper()
+	if !equality.Semantic.DeepEqual(expected, actual) {
+		t.Errorf("%s changed across the round trip: %s", what, diff.ObjectReflectDiff(expected, actual))
+	}
+}
+
+func getTestHubAstarte() *v1alpha2.Astarte {
+	astarte := &v1alpha2.Astarte{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "example-astarte",
+			Namespace:   "astarte",
+			Annotations: map[string]string{"example.com/owner": "astarte-team"},
+		},
+		Spec: v1alpha2.AstarteSpec{
+			Version: "0.11.1",
+			RBAC:    pointy.Bool(true),
+			Paused:  pointy.Bool(true),
+			API:     v1alpha2.AstarteAPISpec{Host: "api.astarte.example.com", SSL: pointy.Bool(true)},
+			RabbitMQ: v1alpha2.AstarteRabbitMQSpec{
+				AstarteGenericClusteredResource: v1alpha2.AstarteGenericClusteredResource{
+					Replicas:         pointy.Int32(3),
+					AntiAffinity:     pointy.Bool(true),
+					AntiAffinityMode: v1alpha2.AntiAffinityRequiredZone,
+					PodDisruptionBudget: &v1alpha2.AstartePodDisruptionBudgetSpec{
+						MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+					},
+				},
+				Storage: &v1alpha2.AstartePersistentStorageSpec{Size: resource.NewQuantity(1<<30, resource.BinarySI)},
+			},
+			Cassandra: v1alpha2.AstarteCassandraSpec{
+				AstarteGenericClusteredResource: v1alpha2.AstarteGenericClusteredResource{
+					Deploy: pointy.Bool(false),
+				},
+				Nodes: "cassandra-0.cassandra:9042",
+			},
+			VerneMQ: v1alpha2.AstarteVerneMQSpec{
+				AstarteGenericClusteredResource: v1alpha2.AstarteGenericClusteredResource{
+					AstartePodSchedulingSpec: v1alpha2.AstartePodSchedulingSpec{
+						NodeSelector:      map[string]string{"example.com/broker": "true"},
+						PriorityClassName: "astarte-broker",
+					},
+				},
+				Host: "broker.astarte.example.com",
+			},
+			CFSSL: v1alpha2.AstarteCFSSLSpec{
+				URL: "http://cfssl:8080",
+				CSRRootCa: &v1alpha2.AstarteCFSSLCSRRootCASpec{
+					CN:    "Astarte Root CA",
+					Names: []v1alpha2.AstarteCFSSLCSRRootCANamesSpec{{C: "IT", O: "Astarte"}},
+				},
+				AstartePodSecuritySpec: v1alpha2.AstartePodSecuritySpec{SeccompProfile: "runtime/default"},
+			},
+			Components: v1alpha2.AstarteComponentsSpec{
+				Housekeeping: v1alpha2.AstarteGenericComponentSpec{
+					API: v1alpha2.AstarteGenericAPISpec{
+						AstarteGenericClusteredResource: v1alpha2.AstarteGenericClusteredResource{
+							Replicas: pointy.Int32(2),
+							AstartePodSecuritySpec: v1alpha2.AstartePodSecuritySpec{
+								Hardened: pointy.Bool(true),
+								SecurityContext: &v1.SecurityContext{
+									ReadOnlyRootFilesystem: pointy.Bool(true),
+								},
+							},
+						},
+						DisableAuthentication: pointy.Bool(false),
+						Autoscaling:           &v1alpha2.AstarteAutoscalingSpec{MinReplicas: pointy.Int32(2), MaxReplicas: 5},
+					},
+					Backend: v1alpha2.AstarteGenericClusteredResource{
+						AstartePodExtensionsSpec: v1alpha2.AstartePodExtensionsSpec{
+							AdditionalEnv: []v1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}},
+							Sidecars:      []v1.Container{{Name: "proxy", Image: "envoyproxy/envoy:v1.14.1"}},
+						},
+					},
+				},
+				DataUpdaterPlant: v1alpha2.AstarteDataUpdaterPlantSpec{
+					AstarteGenericClusteredResource: v1alpha2.AstarteGenericClusteredResource{
+						AntiAffinityMode: v1alpha2.AntiAffinityNone,
+					},
+					DataQueueCount: pointy.Int(256),
+				},
+				AppengineAPI: v1alpha2.AstarteAppengineAPISpec{
+					AstarteGenericAPISpec: v1alpha2.AstarteGenericAPISpec{
+						AstarteGenericClusteredResource: v1alpha2.AstarteGenericClusteredResource{Version: "0.11.2"},
+					},
+					MaxResultsLimit: pointy.Int(1000),
+				},
+				Dashboard: v1alpha2.AstarteDashboardSpec{
+					Host: "dashboard.astarte.example.com",
+					Config: v1alpha2.AstarteDashboardConfigSpec{
+						DefaultRealm: "test",
+						Auth:         []v1alpha2.AstarteDashboardConfigAuthSpec{{Type: "token"}},
+					},
+				},
+			},
+		},
+		Status: v1alpha2.AstarteStatus{
+			ReconciliationPhase: v1alpha2.ReconciliationPhaseReconciled,
+			AstarteVersion:      "0.11.1",
+			OperatorVersion:     "0.11.0",
+			Health:              "green",
+			BaseAPIURL:          "https://api.astarte.example.com",
+			BrokerURL:           "mqtts://broker.astarte.example.com:8883/",
+			Conditions: []v1alpha2.AstarteCondition{{
+				Type:               v1alpha2.AstarteConditionPaused,
+				Status:             v1.ConditionTrue,
+				Reason:             v1alpha2.ReasonPaused,
+				LastTransitionTime: testTime,
+				ObservedGeneration: 4,
+			}},
+			Components: []v1alpha2.AstarteComponentStatus{{
+				Name:            "housekeeping",
+				Deployed:        true,
+				Ready:           true,
+				DesiredReplicas: 2,
+				ReadyReplicas:   2,
+			}},
+			Plan: &v1alpha2.AstartePlan{
+				ObservedGeneration: 4,
+				GeneratedAt:        testTime,
+				Changes: []v1alpha2.AstartePlannedChange{
+					{Action: v1alpha2.PlannedActionUpdate, Kind: "Deployment", Name: "example-astarte-housekeeping", RollsPods: true},
+				},
+			},
+		},
+	}
+	return astarte
+}
+
+// roundTripJSON encodes in and decodes it into out, as happens when Resources are stored or served
+func roundTripJSON(t *testing.T, in, out interface{}) {
+	t.Helper()
+	encoded, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Could not encode %T: %v", in, err)
+	}
+	if err := json.Unmarshal(encoded, out); err != nil {
+		t.Fatalf("Could not decode %T: %v", out, err)
+	}
+}
+
+func TestAstarteHubRoundTrip(t *testing.T) {
+	hub := getTestHubAstarte()
+
+	spoke := &Astarte{}
+	if err := spoke.ConvertFrom(hub.DeepCopy()); err != nil {
+		t.Fatalf("Could not convert from v1alpha2: %v", err)
+	}
+	decoded := &Astarte{}
+	roundTripJSON(t, spoke, decoded)
+	converted := &v1alpha2.Astarte{}
+	if err := decoded.ConvertTo(converted); err != nil {
+		t.Fatalf("Could not convert to v1alpha2: %v", err)
+	}
+
+	assertSemanticEqual(t, "ObjectMeta", hub.ObjectMeta, converted.ObjectMeta)
+	assertSemanticEqual(t, "Spec", hub.Spec, converted.Spec)
+	assertSemanticEqual(t, "Status", hub.Status, converted.Status)
+}
+
+func TestAstarteSpokeRoundTrip(t *testing.T) {
+	spoke := &Astarte{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-astarte", Namespace: "astarte"},
+		Spec: AstarteSpec{
+			Version: "0.10.2",
+			API:     AstarteAPISpec{Host: "api.astarte.example.com"},
+			VerneMQ: AstarteVerneMQSpec{
+				GenericClusteredResource: AstarteGenericClusteredResource{AntiAffinity: pointy.Bool(false)},
+				Host:                     "broker.astarte.example.com",
+			},
+			Components: AstarteComponentsSpec{
+				Pairing: AstarteGenericComponentSpec{
+					API: AstarteGenericAPISpec{
+						GenericClusteredResource: AstarteGenericClusteredResource{Replicas: pointy.Int32(2)},
+						DisableAuthentication:    pointy.Bool(true),
+					},
+				},
+				Dashboard: AstarteDashboardSpec{
+					Config: AstarteDashboardConfigSpec{DefaultAuth: "token"},
+				},
+			},
+		},
+		Status: AstarteStatus{ReconciliationPhase: ReconciliationPhaseReconciled, Health: "green"},
+	}
+
+	hub := &v1alpha2.Astarte{}
+	if err := spoke.DeepCopy().ConvertTo(hub); err != nil {
+		t.Fatalf("Could not convert to v1alpha2: %v", err)
+	}
+	converted := &Astarte{}
+	if err := converted.ConvertFrom(hub); err != nil {
+		t.Fatalf("Could not convert from v1alpha2: %v", err)
+	}
+
+	assertSemanticEqual(t, "Astarte", spoke, converted)
+}
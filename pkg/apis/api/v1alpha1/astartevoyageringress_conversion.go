@@ -0,0 +1,102 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this AstarteVoyagerIngress to the Hub version (v1alpha2)
+func (src *AstarteVoyagerIngress) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha2.AstarteVoyagerIngress)
+	if !ok {
+		return fmt.Errorf("Expected a v1alpha2 AstarteVoyagerIngress, got %T", dstRaw)
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v1alpha2.AstarteVoyagerIngressSpec{
+		ImagePullPolicy: src.Spec.ImagePullPolicy,
+		Astarte:         src.Spec.Astarte,
+		API: v1alpha2.AstarteVoyagerIngressAPISpec{
+			AstarteGenericIngressSpec: convertGenericIngressTo(src.Spec.API.GenericIngressSpec),
+			Cors:                      src.Spec.API.Cors,
+			ExposeHousekeeping:        src.Spec.API.ExposeHousekeeping,
+		},
+		Dashboard: v1alpha2.AstarteVoyagerIngressDashboardSpec(src.Spec.Dashboard),
+		Broker: v1alpha2.AstarteVoyagerIngressBrokerSpec{
+			AstarteGenericIngressSpec: convertGenericIngressTo(src.Spec.Broker.GenericIngressSpec),
+			MaxConnections:            src.Spec.Broker.MaxConnections,
+		},
+		Letsencrypt: v1alpha2.AstarteVoyagerIngressLetsEncryptSpec(src.Spec.Letsencrypt),
+	}
+
+	return restoreVoyagerIngressHubFields(dst)
+}
+
+// ConvertFrom converts from the Hub version (v1alpha2) to this version. The Status is stored in the
+// HubFieldsAnnotation, and restored by ConvertTo.
+func (dst *AstarteVoyagerIngress) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha2.AstarteVoyagerIngress)
+	if !ok {
+		return fmt.Errorf("Expected a v1alpha2 AstarteVoyagerIngress, got %T", srcRaw)
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = AstarteVoyagerIngressSpec{
+		ImagePullPolicy: src.Spec.ImagePullPolicy,
+		Astarte:         src.Spec.Astarte,
+		API: AstarteVoyagerIngressAPISpec{
+			GenericIngressSpec: convertGenericIngressFrom(src.Spec.API.AstarteGenericIngressSpec),
+			Cors:               src.Spec.API.Cors,
+			ExposeHousekeeping: src.Spec.API.ExposeHousekeeping,
+		},
+		Dashboard: AstarteVoyagerIngressDashboardSpec(src.Spec.Dashboard),
+		Broker: AstarteVoyagerIngressBrokerSpec{
+			GenericIngressSpec: convertGenericIngressFrom(src.Spec.Broker.AstarteGenericIngressSpec),
+			MaxConnections:     src.Spec.Broker.MaxConnections,
+		},
+		Letsencrypt: AstarteVoyagerIngressLetsEncryptSpec(src.Spec.Letsencrypt),
+	}
+
+	return storeVoyagerIngressHubFields(src, dst)
+}
+
+func convertGenericIngressTo(in AstarteGenericIngressSpec) v1alpha2.AstarteGenericIngressSpec {
+	out := v1alpha2.AstarteGenericIngressSpec{
+		Deploy:             in.Deploy,
+		Replicas:           in.Replicas,
+		Type:               in.Type,
+		LoadBalancerIP:     in.LoadBalancerIP,
+		TLSSecret:          in.TLSSecret,
+		TLSRef:             in.TLSRef,
+		AnnotationsService: in.AnnotationsService,
+	}
+	// v1alpha1 carries the Node Selector in the JSON form Voyager expects in its annotation. Anything which isn't
+	// a valid JSON object was refused by Voyager anyway, hence it is dropped.
+	if in.NodeSelector != "" {
+		nodeSelector := map[string]string{}
+		if err := json.Unmarshal([]byte(in.NodeSelector), &nodeSelector); err == nil {
+			out.NodeSelector = nodeSelector
+		}
+	}
+	return out
+}
+
+func convertGenericIngressFrom(in v1alpha2.AstarteGenericIngressSpec) AstarteGenericIngressSpec {
+	out := AstarteGenericIngressSpec{
+		Deploy:             in.Deploy,
+		Replicas:           in.Replicas,
+		Type:               in.Type,
+		LoadBalancerIP:     in.LoadBalancerIP,
+		TLSSecret:          in.TLSSecret,
+		TLSRef:             in.TLSRef,
+		AnnotationsService: in.AnnotationsService,
+	}
+	if len(in.NodeSelector) > 0 {
+		nodeSelector, _ := json.Marshal(in.NodeSelector)
+		out.NodeSelector = string(nodeSelector)
+	}
+	return out
+}
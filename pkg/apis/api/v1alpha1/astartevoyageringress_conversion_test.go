@@ -0,0 +1,59 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	"github.com/openlyinc/pointy"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAstarteVoyagerIngressHubRoundTrip(t *testing.T) {
+	hub := &v1alpha2.AstarteVoyagerIngress{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-ingress", Namespace: "astarte"},
+		Spec: v1alpha2.AstarteVoyagerIngressSpec{
+			Astarte: "example-astarte",
+			API: v1alpha2.AstarteVoyagerIngressAPISpec{
+				AstarteGenericIngressSpec: v1alpha2.AstarteGenericIngressSpec{
+					Replicas:     pointy.Int32(2),
+					Type:         "LoadBalancer",
+					NodeSelector: map[string]string{"example.com/ingress": "true"},
+				},
+				ExposeHousekeeping: pointy.Bool(true),
+			},
+			Broker: v1alpha2.AstarteVoyagerIngressBrokerSpec{
+				AstarteGenericIngressSpec: v1alpha2.AstarteGenericIngressSpec{TLSSecret: "broker-tls"},
+				MaxConnections:            pointy.Int(10000),
+			},
+			Letsencrypt: v1alpha2.AstarteVoyagerIngressLetsEncryptSpec{
+				Use:     pointy.Bool(true),
+				Domains: []string{"api.astarte.example.com"},
+			},
+		},
+		Status: v1alpha2.AstarteVoyagerIngressStatus{
+			API: v1alpha2.AstarteVoyagerIngressLoadBalancerStatus{
+				Deployed:  true,
+				Ready:     true,
+				Addresses: []string{"203.0.113.10"},
+			},
+			Certificate: v1alpha2.AstarteVoyagerIngressCertificateStatus{
+				State:    v1alpha2.CertificateStatePending,
+				Domains:  []string{"api.astarte.example.com"},
+				NotAfter: &testTime,
+			},
+		},
+	}
+
+	spoke := &AstarteVoyagerIngress{}
+	if err := spoke.ConvertFrom(hub.DeepCopy()); err != nil {
+		t.Fatalf("Could not convert from v1alpha2: %v", err)
+	}
+	decoded := &AstarteVoyagerIngress{}
+	roundTripJSON(t, spoke, decoded)
+	converted := &v1alpha2.AstarteVoyagerIngress{}
+	if err := decoded.ConvertTo(converted); err != nil {
+		t.Fatalf("Could not convert to v1alpha2: %v", err)
+	}
+
+	assertSemanticEqual(t, "AstarteVoyagerIngress", hub, converted)
+}
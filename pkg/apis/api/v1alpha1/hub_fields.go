@@ -0,0 +1,227 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	"github.com/openlyinc/pointy"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HubFieldsAnnotation holds, on v1alpha1 Resources, the fields of their v1alpha2 counterpart which v1alpha1 can't
+// represent. They are restored when converting back to v1alpha2, so that writes from v1alpha1 clients don't wipe them.
+const HubFieldsAnnotation = "api.astarte-platform.org/v1alpha2-fields"
+
+// clusteredResourceHubFields holds the fields of an AstarteGenericClusteredResource missing in v1alpha1
+type clusteredResourceHubFields struct {
+	// AntiAffinity is collapsed together with AntiAffinityMode in v1alpha1, and is kept to be restored with it
+	AntiAffinity                      *bool                                    `json:"antiAffinity,omitempty"`
+	AntiAffinityMode                  v1alpha2.AstarteAntiAffinityMode         `json:"antiAffinityMode,omitempty"`
+	PodDisruptionBudget               *v1alpha2.AstartePodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+	v1alpha2.AstartePodSchedulingSpec `json:",inline"`
+	v1alpha2.AstartePodExtensionsSpec `json:",inline"`
+	v1alpha2.AstartePodSecuritySpec   `json:",inline"`
+}
+
+// astarteHubFields holds the fields of a v1alpha2 Astarte missing in v1alpha1. Clustered resources and autoscaling
+// settings are keyed by their path in the Spec.
+type astarteHubFields struct {
+	Paused             *bool                                       `json:"paused,omitempty"`
+	ClusteredResources map[string]clusteredResourceHubFields       `json:"clusteredResources,omitempty"`
+	Autoscaling        map[string]*v1alpha2.AstarteAutoscalingSpec `json:"autoscaling,omitempty"`
+	CFSSL              *clusteredResourceHubFields                 `json:"cfssl,omitempty"`
+	Conditions         []v1alpha2.AstarteCondition                 `json:"conditions,omitempty"`
+	Components         []v1alpha2.AstarteComponentStatus           `json:"components,omitempty"`
+	Plan               *v1alpha2.AstartePlan                       `json:"plan,omitempty"`
+}
+
+// voyagerIngressHubFields holds the fields of a v1alpha2 AstarteVoyagerIngress missing in v1alpha1
+type voyagerIngressHubFields struct {
+	Status *v1alpha2.AstarteVoyagerIngressStatus `json:"status,omitempty"`
+}
+
+func getClusteredResources(spec *v1alpha2.AstarteSpec) map[string]*v1alpha2.AstarteGenericClusteredResource {
+	return map[string]*v1alpha2.AstarteGenericClusteredResource{
+		"rabbitmq":                &spec.RabbitMQ.AstarteGenericClusteredResource,
+		"cassandra":               &spec.Cassandra.AstarteGenericClusteredResource,
+		"vernemq":                 &spec.VerneMQ.AstarteGenericClusteredResource,
+		"housekeeping.api":        &spec.Components.Housekeeping.API.AstarteGenericClusteredResource,
+		"housekeeping.backend":    &spec.Components.Housekeeping.Backend,
+		"realmManagement.api":     &spec.Components.RealmManagement.API.AstarteGenericClusteredResource,
+		"realmManagement.backend": &spec.Components.RealmManagement.Backend,
+		"pairing.api":             &spec.Components.Pairing.API.AstarteGenericClusteredResource,
+		"pairing.backend":         &spec.Components.Pairing.Backend,
+		"dataUpdaterPlant":        &spec.Components.DataUpdaterPlant.AstarteGenericClusteredResource,
+		"appengineApi":            &spec.Components.AppengineAPI.AstarteGenericClusteredResource,
+		"triggerEngine":           &spec.Components.TriggerEngine,
+		"dashboard":               &spec.Components.Dashboard.AstarteGenericClusteredResource,
+	}
+}
+
+func getAutoscalingSpecs(spec *v1alpha2.AstarteSpec) map[string]**v1alpha2.AstarteAutoscalingSpec {
+	return map[string]**v1alpha2.AstarteAutoscalingSpec{
+		"housekeeping.api":    &spec.Components.Housekeeping.API.Autoscaling,
+		"realmManagement.api": &spec.Components.RealmManagement.API.Autoscaling,
+		"pairing.api":         &spec.Components.Pairing.API.Autoscaling,
+		"appengineApi":        &spec.Components.AppengineAPI.Autoscaling,
+		"dashboard":           &spec.Components.Dashboard.Autoscaling,
+	}
+}
+
+func getClusteredResourceHubFields(in v1alpha2.AstarteGenericClusteredResource) clusteredResourceHubFields {
+	out := clusteredResourceHubFields{
+		AntiAffinityMode:         in.AntiAffinityMode,
+		PodDisruptionBudget:      in.PodDisruptionBudget,
+		AstartePodSchedulingSpec: in.AstartePodSchedulingSpec,
+		AstartePodExtensionsSpec: in.AstartePodExtensionsSpec,
+		AstartePodSecuritySpec:   in.AstartePodSecuritySpec,
+	}
+	if in.AntiAffinityMode != "" {
+		out.AntiAffinity = in.AntiAffinity
+	}
+	return out
+}
+
+// restoreClusteredResourceHubFields sets the fields of out missing in v1alpha1 from in. The Anti Affinity Mode is
+// restored only if the v1alpha1 client didn't change the antiAffinity flag it was collapsed into.
+func restoreClusteredResourceHubFields(in clusteredResourceHubFields, out *v1alpha2.AstarteGenericClusteredResource) {
+	out.PodDisruptionBudget = in.PodDisruptionBudget
+	out.AstartePodSchedulingSpec = in.AstartePodSchedulingSpec
+	out.AstartePodExtensionsSpec = in.AstartePodExtensionsSpec
+	out.AstartePodSecuritySpec = in.AstartePodSecuritySpec
+	if in.AntiAffinityMode != "" && pointy.BoolValue(out.AntiAffinity, true) == (in.AntiAffinityMode != v1alpha2.AntiAffinityNone) {
+		out.AntiAffinityMode = in.AntiAffinityMode
+		out.AntiAffinity = in.AntiAffinity
+	}
+}
+
+func isEmptyClusteredResourceHubFields(f clusteredResourceHubFields) bool {
+	encoded, _ := json.Marshal(f)
+	return string(encoded) == "{}"
+}
+
+// storeAstarteHubFields stores the fields of src missing in v1alpha1 in the HubFieldsAnnotation of dst
+func storeAstarteHubFields(src *v1alpha2.Astarte, dst metav1.Object) error {
+	fields := astarteHubFields{
+		Paused:             src.Spec.Paused,
+		ClusteredResources: map[string]clusteredResourceHubFields{},
+		Autoscaling:        map[string]*v1alpha2.AstarteAutoscalingSpec{},
+		Conditions:         src.Status.Conditions,
+		Components:         src.Status.Components,
+		Plan:               src.Status.Plan,
+	}
+	cfssl := clusteredResourceHubFields{
+		AstartePodSchedulingSpec: src.Spec.CFSSL.AstartePodSchedulingSpec,
+		AstartePodExtensionsSpec: src.Spec.CFSSL.AstartePodExtensionsSpec,
+		AstartePodSecuritySpec:   src.Spec.CFSSL.AstartePodSecuritySpec,
+	}
+	if !isEmptyClusteredResourceHubFields(cfssl) {
+		fields.CFSSL = &cfssl
+	}
+	spec := src.Spec
+	for path, resource := range getClusteredResources(&spec) {
+		if f := getClusteredResourceHubFields(*resource); !isEmptyClusteredResourceHubFields(f) {
+			fields.ClusteredResources[path] = f
+		}
+	}
+	for path, autoscaling := range getAutoscalingSpecs(&spec) {
+		if *autoscaling != nil {
+			fields.Autoscaling[path] = *autoscaling
+		}
+	}
+	return storeHubFields(fields, dst)
+}
+
+// restoreAstarteHubFields restores the fields stored by storeAstarteHubFields in dst, and drops the annotation
+func restoreAstarteHubFields(dst *v1alpha2.Astarte) error {
+	fields := astarteHubFields{}
+	if found, err := restoreHubFields(dst, &fields); err != nil || !found {
+		return err
+	}
+
+	dst.Spec.Paused = fields.Paused
+	for path, resource := range getClusteredResources(&dst.Spec) {
+		if f, ok := fields.ClusteredResources[path]; ok {
+			restoreClusteredResourceHubFields(f, resource)
+		}
+	}
+	for path, autoscaling := range getAutoscalingSpecs(&dst.Spec) {
+		*autoscaling = fields.Autoscaling[path]
+	}
+	if fields.CFSSL != nil {
+		dst.Spec.CFSSL.AstartePodSchedulingSpec = fields.CFSSL.AstartePodSchedulingSpec
+		dst.Spec.CFSSL.AstartePodExtensionsSpec = fields.CFSSL.AstartePodExtensionsSpec
+		dst.Spec.CFSSL.AstartePodSecuritySpec = fields.CFSSL.AstartePodSecuritySpec
+	}
+	dst.Status.Conditions = fields.Conditions
+	dst.Status.Components = fields.Components
+	dst.Status.Plan = fields.Plan
+	return nil
+}
+
+// storeVoyagerIngressHubFields stores the fields of src missing in v1alpha1 in the HubFieldsAnnotation of dst
+func storeVoyagerIngressHubFields(src *v1alpha2.AstarteVoyagerIngress, dst metav1.Object) error {
+	fields := voyagerIngressHubFields{}
+	if !reflect.DeepEqual(src.Status, v1alpha2.AstarteVoyagerIngressStatus{}) {
+		status := src.Status
+		fields.Status = &status
+	}
+	return storeHubFields(fields, dst)
+}
+
+// restoreVoyagerIngressHubFields restores the fields stored by storeVoyagerIngressHubFields in dst, and drops the
+// annotation
+func restoreVoyagerIngressHubFields(dst *v1alpha2.AstarteVoyagerIngress) error {
+	fields := voyagerIngressHubFields{}
+	if found, err := restoreHubFields(dst, &fields); err != nil || !found {
+		return err
+	}
+	if fields.Status != nil {
+		dst.Status = *fields.Status
+	}
+	return nil
+}
+
+// storeHubFields sets the HubFieldsAnnotation of dst to fields, unless they're all empty. Annotations are copied, as
+// dst shares its ObjectMeta with the object it was converted from.
+func storeHubFields(fields interface{}, dst metav1.Object) error {
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	if string(encoded) == "{}" {
+		return nil
+	}
+	annotations := map[string]string{}
+	for k, v := range dst.GetAnnotations() {
+		annotations[k] = v
+	}
+	annotations[HubFieldsAnnotation] = string(encoded)
+	dst.SetAnnotations(annotations)
+	return nil
+}
+
+// restoreHubFields decodes the HubFieldsAnnotation of dst into fields, and removes it. It returns whether the
+// annotation was found.
+func restoreHubFields(dst metav1.Object, fields interface{}) (bool, error) {
+	encoded, ok := dst.GetAnnotations()[HubFieldsAnnotation]
+	if !ok {
+		return false, nil
+	}
+	if err := json.Unmarshal([]byte(encoded), fields); err != nil {
+		return false, err
+	}
+
+	annotations := map[string]string{}
+	for k, v := range dst.GetAnnotations() {
+		if k != HubFieldsAnnotation {
+			annotations[k] = v
+		}
+	}
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	dst.SetAnnotations(annotations)
+	return true, nil
+}
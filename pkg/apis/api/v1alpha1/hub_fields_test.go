@@ -0,0 +1,86 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	"github.com/openlyinc/pointy"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStoreAstarteHubFieldsWithoutHubFields(t *testing.T) {
+	hub := &v1alpha2.Astarte{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-astarte", Annotations: map[string]string{"example.com/owner": "astarte-team"}},
+		Spec:       v1alpha2.AstarteSpec{Version: "0.11.1"},
+	}
+
+	spoke := &Astarte{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("Could not convert from v1alpha2: %v", err)
+	}
+	if _, ok := spoke.Annotations[HubFieldsAnnotation]; ok {
+		t.Errorf("Expected no %s annotation, got %s", HubFieldsAnnotation, spoke.Annotations[HubFieldsAnnotation])
+	}
+}
+
+func TestRestoreAstarteHubFieldsDropsAnnotation(t *testing.T) {
+	hub := &v1alpha2.Astarte{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-astarte", Annotations: map[string]string{"example.com/owner": "astarte-team"}},
+		Spec:       v1alpha2.AstarteSpec{Version: "0.11.1", Paused: pointy.Bool(true)},
+	}
+
+	spoke := &Astarte{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("Could not convert from v1alpha2: %v", err)
+	}
+	if _, ok := spoke.Annotations[HubFieldsAnnotation]; !ok {
+		t.Fatalf("Expected the %s annotation, got %v", HubFieldsAnnotation, spoke.Annotations)
+	}
+	if _, ok := hub.Annotations[HubFieldsAnnotation]; ok {
+		t.Errorf("The annotations of the converted Resource were modified")
+	}
+
+	converted := &v1alpha2.Astarte{}
+	if err := spoke.ConvertTo(converted); err != nil {
+		t.Fatalf("Could not convert to v1alpha2: %v", err)
+	}
+	if _, ok := converted.Annotations[HubFieldsAnnotation]; ok {
+		t.Errorf("Expected the %s annotation to be dropped", HubFieldsAnnotation)
+	}
+	if converted.Annotations["example.com/owner"] != "astarte-team" {
+		t.Errorf("Expected the other annotations to be kept, got %v", converted.Annotations)
+	}
+}
+
+func TestRestoreAntiAffinityMode(t *testing.T) {
+	testCases := []struct {
+		name         string
+		antiAffinity *bool
+		expectedMode v1alpha2.AstarteAntiAffinityMode
+	}{
+		{"unchanged", pointy.Bool(true), v1alpha2.AntiAffinityRequiredZone},
+		{"disabled by a v1alpha1 client", pointy.Bool(false), ""},
+		{"unset by a v1alpha1 client", nil, v1alpha2.AntiAffinityRequiredZone},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hub := &v1alpha2.Astarte{ObjectMeta: metav1.ObjectMeta{Name: "example-astarte"}}
+			hub.Spec.VerneMQ.AntiAffinityMode = v1alpha2.AntiAffinityRequiredZone
+
+			spoke := &Astarte{}
+			if err := spoke.ConvertFrom(hub); err != nil {
+				t.Fatalf("Could not convert from v1alpha2: %v", err)
+			}
+			spoke.Spec.VerneMQ.GenericClusteredResource.AntiAffinity = tc.antiAffinity
+
+			converted := &v1alpha2.Astarte{}
+			if err := spoke.ConvertTo(converted); err != nil {
+				t.Fatalf("Could not convert to v1alpha2: %v", err)
+			}
+			if converted.Spec.VerneMQ.AntiAffinityMode != tc.expectedMode {
+				t.Errorf("Expected Anti Affinity Mode %q, got %q", tc.expectedMode, converted.Spec.VerneMQ.AntiAffinityMode)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+)
+
+// The structs below hold their common settings in a named field tagged `json:",inline"`, which encoding/json does
+// not inline: such settings are serialized in a nested object named after the field. Resources written following
+// the CRD schema carry them inline though, so both forms are accepted when decoding, with inline values winning,
+// and they are always encoded inline. This allows converting any existing v1alpha1 resource to and from v1alpha2
+// without losing information.
+
+// UnmarshalJSON decodes an AstarteGenericAPISpec, accepting inline settings
+func (s *AstarteGenericAPISpec) UnmarshalJSON(data []byte) error {
+	type plain AstarteGenericAPISpec
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &s.GenericClusteredResource)
+}
+
+// UnmarshalJSON decodes an AstarteRabbitMQSpec, accepting inline settings
+func (s *AstarteRabbitMQSpec) UnmarshalJSON(data []byte) error {
+	type plain AstarteRabbitMQSpec
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &s.GenericClusteredResource)
+}
+
+// UnmarshalJSON decodes an AstarteCassandraSpec, accepting inline settings
+func (s *AstarteCassandraSpec) UnmarshalJSON(data []byte) error {
+	type plain AstarteCassandraSpec
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &s.GenericClusteredResource)
+}
+
+// UnmarshalJSON decodes an AstarteVerneMQSpec, accepting inline settings
+func (s *AstarteVerneMQSpec) UnmarshalJSON(data []byte) error {
+	type plain AstarteVerneMQSpec
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &s.GenericClusteredResource)
+}
+
+// UnmarshalJSON decodes an AstarteDataUpdaterPlantSpec, accepting inline settings
+func (s *AstarteDataUpdaterPlantSpec) UnmarshalJSON(data []byte) error {
+	type plain AstarteDataUpdaterPlantSpec
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &s.GenericClusteredResource)
+}
+
+// UnmarshalJSON decodes an AstarteAppengineAPISpec, accepting inline settings
+func (s *AstarteAppengineAPISpec) UnmarshalJSON(data []byte) error {
+	type plain AstarteAppengineAPISpec
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &s.GenericAPISpec)
+}
+
+// UnmarshalJSON decodes an AstarteDashboardSpec, accepting inline settings
+func (s *AstarteDashboardSpec) UnmarshalJSON(data []byte) error {
+	type plain AstarteDashboardSpec
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &s.GenericClusteredResource); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &s.Config)
+}
+
+// UnmarshalJSON decodes an AstarteVoyagerIngressAPISpec, accepting inline settings
+func (s *AstarteVoyagerIngressAPISpec) UnmarshalJSON(data []byte) error {
+	type plain AstarteVoyagerIngressAPISpec
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &s.GenericIngressSpec)
+}
+
+// UnmarshalJSON decodes an AstarteVoyagerIngressBrokerSpec, accepting inline settings
+func (s *AstarteVoyagerIngressBrokerSpec) UnmarshalJSON(data []byte) error {
+	type plain AstarteVoyagerIngressBrokerSpec
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &s.GenericIngressSpec)
+}
+
+// MarshalJSON encodes an AstarteGenericAPISpec, inlining its settings
+func (s AstarteGenericAPISpec) MarshalJSON() ([]byte, error) {
+	type plain AstarteGenericAPISpec
+	return marshalInline(plain(s), map[string]interface{}{
+		"GenericClusteredResource": s.GenericClusteredResource,
+	})
+}
+
+// MarshalJSON encodes an AstarteRabbitMQSpec, inlining its settings
+func (s AstarteRabbitMQSpec) MarshalJSON() ([]byte, error) {
+	type plain AstarteRabbitMQSpec
+	return marshalInline(plain(s), map[string]interface{}{
+		"GenericClusteredResource": s.GenericClusteredResource,
+	})
+}
+
+// MarshalJSON encodes an AstarteCassandraSpec, inlining its settings
+func (s AstarteCassandraSpec) MarshalJSON() ([]byte, error) {
+	type plain AstarteCassandraSpec
+	return marshalInline(plain(s), map[string]interface{}{
+		"GenericClusteredResource": s.GenericClusteredResource,
+	})
+}
+
+// MarshalJSON encodes an AstarteVerneMQSpec, inlining its settings
+func (s AstarteVerneMQSpec) MarshalJSON() ([]byte, error) {
+	type plain AstarteVerneMQSpec
+	return marshalInline(plain(s), map[string]interface{}{
+		"GenericClusteredResource": s.GenericClusteredResource,
+	})
+}
+
+// MarshalJSON encodes an AstarteDataUpdaterPlantSpec, inlining its settings
+func (s AstarteDataUpdaterPlantSpec) MarshalJSON() ([]byte, error) {
+	type plain AstarteDataUpdaterPlantSpec
+	return marshalInline(plain(s), map[string]interface{}{
+		"GenericClusteredResource": s.GenericClusteredResource,
+	})
+}
+
+// MarshalJSON encodes an AstarteAppengineAPISpec, inlining its settings
+func (s AstarteAppengineAPISpec) MarshalJSON() ([]byte, error) {
+	type plain AstarteAppengineAPISpec
+	return marshalInline(plain(s), map[string]interface{}{
+		"GenericAPISpec": s.GenericAPISpec,
+	})
+}
+
+// MarshalJSON encodes an AstarteDashboardSpec, inlining its settings
+func (s AstarteDashboardSpec) MarshalJSON() ([]byte, error) {
+	type plain AstarteDashboardSpec
+	return marshalInline(plain(s), map[string]interface{}{
+		"GenericClusteredResource": s.GenericClusteredResource,
+		"Config":                   s.Config,
+	})
+}
+
+// MarshalJSON encodes an AstarteVoyagerIngressAPISpec, inlining its settings
+func (s AstarteVoyagerIngressAPISpec) MarshalJSON() ([]byte, error) {
+	type plain AstarteVoyagerIngressAPISpec
+	return marshalInline(plain(s), map[string]interface{}{
+		"GenericIngressSpec": s.GenericIngressSpec,
+	})
+}
+
+// MarshalJSON encodes an AstarteVoyagerIngressBrokerSpec, inlining its settings
+func (s AstarteVoyagerIngressBrokerSpec) MarshalJSON() ([]byte, error) {
+	type plain AstarteVoyagerIngressBrokerSpec
+	return marshalInline(plain(s), map[string]interface{}{
+		"GenericIngressSpec": s.GenericIngressSpec,
+	})
+}
+
+// marshalInline encodes obj, replacing the fields named in inline with the settings they hold
+func marshalInline(obj interface{}, inline map[string]interface{}) ([]byte, error) {
+	encoded := map[string]json.RawMessage{}
+	if err := remarshal(obj, &encoded); err != nil {
+		return nil, err
+	}
+	for field, value := range inline {
+		delete(encoded, field)
+		settings := map[string]json.RawMessage{}
+		if err := remarshal(value, &settings); err != nil {
+			return nil, err
+		}
+		for k, v := range settings {
+			encoded[k] = v
+		}
+	}
+	return json.Marshal(encoded)
+}
+
+func remarshal(in interface{}, out *map[string]json.RawMessage) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
@@ -0,0 +1,112 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestUnmarshalInlineSettings(t *testing.T) {
+	testCases := []struct {
+		name   string
+		nested string
+		inline string
+		new    func() interface{}
+	}{
+		{
+			"generic api",
+			`{"GenericClusteredResource": {"replicas": 2, "antiAffinity": false}, "disableAuthentication": true}`,
+			`{"replicas": 2, "antiAffinity": false, "disableAuthentication": true}`,
+			func() interface{} { return &AstarteGenericAPISpec{} },
+		},
+		{
+			"rabbitmq",
+			`{"GenericClusteredResource": {"version": "3.7.21"}, "additionalPlugins": ["rabbitmq_shovel"]}`,
+			`{"version": "3.7.21", "additionalPlugins": ["rabbitmq_shovel"]}`,
+			func() interface{} { return &AstarteRabbitMQSpec{} },
+		},
+		{
+			"cassandra",
+			`{"GenericClusteredResource": {"deploy": false}, "nodes": "cassandra:9042"}`,
+			`{"deploy": false, "nodes": "cassandra:9042"}`,
+			func() interface{} { return &AstarteCassandraSpec{} },
+		},
+		{
+			"vernemq",
+			`{"GenericClusteredResource": {"image": "astarte/vernemq:0.11.1"}, "host": "broker.astarte.example.com"}`,
+			`{"image": "astarte/vernemq:0.11.1", "host": "broker.astarte.example.com"}`,
+			func() interface{} { return &AstarteVerneMQSpec{} },
+		},
+		{
+			"data updater plant",
+			`{"GenericClusteredResource": {"replicas": 3}, "dataQueueCount": 256}`,
+			`{"replicas": 3, "dataQueueCount": 256}`,
+			func() interface{} { return &AstarteDataUpdaterPlantSpec{} },
+		},
+		{
+			"appengine api",
+			`{"GenericAPISpec": {"replicas": 2, "disableAuthentication": true}, "maxResultsLimit": 1000}`,
+			`{"replicas": 2, "disableAuthentication": true, "maxResultsLimit": 1000}`,
+			func() interface{} { return &AstarteAppengineAPISpec{} },
+		},
+		{
+			"dashboard",
+			`{"GenericClusteredResource": {"deploy": true}, "Config": {"defaultRealm": "test"}, "host": "dashboard.astarte.example.com"}`,
+			`{"deploy": true, "defaultRealm": "test", "host": "dashboard.astarte.example.com"}`,
+			func() interface{} { return &AstarteDashboardSpec{} },
+		},
+		{
+			"voyager ingress api",
+			`{"GenericIngressSpec": {"type": "LoadBalancer", "replicas": 2}, "cors": true}`,
+			`{"type": "LoadBalancer", "replicas": 2, "cors": true}`,
+			func() interface{} { return &AstarteVoyagerIngressAPISpec{} },
+		},
+		{
+			"voyager ingress broker",
+			`{"GenericIngressSpec": {"tlsSecret": "broker-tls"}, "maxConnections": 10000}`,
+			`{"tlsSecret": "broker-tls", "maxConnections": 10000}`,
+			func() interface{} { return &AstarteVoyagerIngressBrokerSpec{} },
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			nested, inline := tc.new(), tc.new()
+			if err := json.Unmarshal([]byte(tc.nested), nested); err != nil {
+				t.Fatalf("Could not decode nested settings: %v", err)
+			}
+			if err := json.Unmarshal([]byte(tc.inline), inline); err != nil {
+				t.Fatalf("Could not decode inline settings: %v", err)
+			}
+			assertSemanticEqual(t, "Nested settings", inline, nested)
+
+			// Whatever the form they were decoded from, settings are encoded inline and decoded back as they were
+			encoded, err := json.Marshal(nested)
+			if err != nil {
+				t.Fatalf("Could not encode settings: %v", err)
+			}
+			if strings.Contains(string(encoded), `"Generic`) || strings.Contains(string(encoded), `"Config"`) {
+				t.Errorf("Expected inline settings, got %s", encoded)
+			}
+			decoded := tc.new()
+			if err := json.Unmarshal(encoded, decoded); err != nil {
+				t.Fatalf("Could not decode encoded settings: %v", err)
+			}
+			assertSemanticEqual(t, "Encoded settings", nested, decoded)
+		})
+	}
+}
+
+func TestUnmarshalInlineSettingsWin(t *testing.T) {
+	spec := &AstarteGenericAPISpec{}
+	if err := json.Unmarshal([]byte(`{"GenericClusteredResource": {"replicas": 1, "version": "0.11.0"}, "replicas": 3}`), spec); err != nil {
+		t.Fatalf("Could not decode settings: %v", err)
+	}
+
+	if spec.GenericClusteredResource.Replicas == nil || *spec.GenericClusteredResource.Replicas != 3 {
+		t.Errorf("Expected the inline replicas to win, got %v", spec.GenericClusteredResource.Replicas)
+	}
+	if spec.GenericClusteredResource.Version != "0.11.0" {
+		t.Errorf("Expected the nested version to be kept, got %q", spec.GenericClusteredResource.Version)
+	}
+}
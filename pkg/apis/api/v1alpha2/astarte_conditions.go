@@ -0,0 +1,120 @@
+package v1alpha2
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AstarteConditionType describes the type of a Condition of an Astarte Resource
+type AstarteConditionType string
+
+const (
+	// AstarteConditionReady means all deployed Astarte components and dependencies are ready to serve requests
+	AstarteConditionReady AstarteConditionType = "Ready"
+	// AstarteConditionProgressing means the Operator is still driving the cluster towards the requested state
+	AstarteConditionProgressing AstarteConditionType = "Progressing"
+	// AstarteConditionDegraded means the last reconciliation failed, and the cluster might not reflect the Spec
+	AstarteConditionDegraded AstarteConditionType = "Degraded"
+	// AstarteConditionUpgrading means an upgrade to a new Astarte version is in progress
+	AstarteConditionUpgrading AstarteConditionType = "Upgrading"
+	// AstarteConditionDependenciesReady means RabbitMQ, Cassandra and CFSSL are ready, when deployed by the Operator
+	AstarteConditionDependenciesReady AstarteConditionType = "DependenciesReady"
+	// AstarteConditionPaused means the Operator is only reporting the status of the cluster, without changing it
+	AstarteConditionPaused AstarteConditionType = "Paused"
+)
+
+// Reasons used in Astarte Conditions
+const (
+	// ReasonReconcileSucceeded is used when the last reconciliation went through
+	ReasonReconcileSucceeded = "ReconcileSucceeded"
+	// ReasonReconcileFailed is used when the last reconciliation returned an error
+	ReasonReconcileFailed = "ReconcileFailed"
+	// ReasonUnsupportedVersion is used when the requested Astarte version can't be handled by this Operator
+	ReasonUnsupportedVersion = "UnsupportedVersion"
+	// ReasonTransientError is used when the last reconciliation failed because of an error expected to go away on its own
+	ReasonTransientError = "TransientError"
+	// ReasonInvalidSpec is used when the Spec can't be reconciled as it is, and has to be fixed
+	ReasonInvalidSpec = "InvalidSpec"
+	// ReasonDependencyNotReady is used when something Astarte relies upon, but the Operator doesn't manage, is missing
+	ReasonDependencyNotReady = "DependencyNotReady"
+	// ReasonUpgradeBlocked is used when an upgrade can't proceed until the cluster or its status is fixed
+	ReasonUpgradeBlocked = "UpgradeBlocked"
+	// ReasonComponentsReady is used when all Astarte components are ready
+	ReasonComponentsReady = "ComponentsReady"
+	// ReasonComponentsNotReady is used when at least one Astarte component is not ready
+	ReasonComponentsNotReady = "ComponentsNotReady"
+	// ReasonDependenciesReady is used when all dependencies are ready
+	ReasonDependenciesReady = "DependenciesReady"
+	// ReasonDependenciesNotReady is used when at least one dependency is not ready
+	ReasonDependenciesNotReady = "DependenciesNotReady"
+	// ReasonUpgradeNotRequired is used when no upgrade was ever needed
+	ReasonUpgradeNotRequired = "UpgradeNotRequired"
+	// ReasonUpgradeInProgress is used while an upgrade is running
+	ReasonUpgradeInProgress = "UpgradeInProgress"
+	// ReasonUpgradeSucceeded is used when the last upgrade completed successfully
+	ReasonUpgradeSucceeded = "UpgradeSucceeded"
+	// ReasonUpgradeFailed is used when the last upgrade failed
+	ReasonUpgradeFailed = "UpgradeFailed"
+	// ReasonPaused is used when the reconciliation was paused through the Spec or an annotation
+	ReasonPaused = "Paused"
+	// ReasonNotPaused is used when the reconciliation is running normally
+	ReasonNotPaused = "NotPaused"
+)
+
+// AstarteCondition describes the state of an Astarte Resource at a certain point
+type AstarteCondition struct {
+	// Type of the Condition
+	Type AstarteConditionType `json:"type"`
+	// Status of the Condition, one of True, False, Unknown
+	Status v1.ConditionStatus `json:"status"`
+	// The reason for the Condition's last transition, in CamelCase
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// A human readable message indicating details about the transition
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Last time the Condition transitioned from one status to another
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// The Resource's generation the Condition was computed from
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// GetCondition returns the Condition of the given type, or nil if it was never set
+func (s *AstarteStatus) GetCondition(conditionType AstarteConditionType) *AstarteCondition {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == conditionType {
+			return &s.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// IsConditionTrue returns whether the Condition of the given type is set and True
+func (s *AstarteStatus) IsConditionTrue(conditionType AstarteConditionType) bool {
+	condition := s.GetCondition(conditionType)
+	return condition != nil && condition.Status == v1.ConditionTrue
+}
+
+// SetCondition sets the Condition of the given type, observing the Resource's current generation.
+// LastTransitionTime is updated only when the Condition's status actually changes.
+func (a *Astarte) SetCondition(conditionType AstarteConditionType, status v1.ConditionStatus, reason, message string) {
+	newCondition := AstarteCondition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: a.Generation,
+	}
+
+	if existing := a.Status.GetCondition(conditionType); existing != nil {
+		if existing.Status == status {
+			newCondition.LastTransitionTime = existing.LastTransitionTime
+		}
+		*existing = newCondition
+		return
+	}
+	a.Status.Conditions = append(a.Status.Conditions, newCondition)
+}
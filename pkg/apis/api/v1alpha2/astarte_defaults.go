@@ -0,0 +1,111 @@
+package v1alpha2
+
+import (
+	"github.com/openlyinc/pointy"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Default materializes in the Spec all the defaults the Operator would otherwise apply while reconciling.
+// Versions and images are never defaulted, as they are derived from the main Astarte Version and must follow it
+// upon upgrades.
+func (r *Astarte) Default() {
+	spec := &r.Spec
+
+	if spec.ImagePullPolicy == nil {
+		pullPolicy := v1.PullIfNotPresent
+		spec.ImagePullPolicy = &pullPolicy
+	}
+	if spec.RBAC == nil {
+		spec.RBAC = pointy.Bool(true)
+	}
+	if spec.API.SSL == nil {
+		spec.API.SSL = pointy.Bool(true)
+	}
+
+	// Dependencies
+	defaultGenericClusteredResource(&spec.RabbitMQ.AstarteGenericClusteredResource)
+	if spec.RabbitMQ.Connection != nil && spec.RabbitMQ.Connection.Port == nil {
+		spec.RabbitMQ.Connection.Port = pointy.Int16(5672)
+	}
+	spec.RabbitMQ.Storage = defaultPersistentStorage(spec.RabbitMQ.Storage, resource.NewScaledQuantity(4, resource.Giga), spec.StorageClassName)
+
+	defaultGenericClusteredResource(&spec.Cassandra.AstarteGenericClusteredResource)
+	if spec.Cassandra.MaxHeapSize == "" {
+		spec.Cassandra.MaxHeapSize = "1024M"
+	}
+	if spec.Cassandra.HeapNewSize == "" {
+		spec.Cassandra.HeapNewSize = "256M"
+	}
+	spec.Cassandra.Storage = defaultPersistentStorage(spec.Cassandra.Storage, resource.NewScaledQuantity(30, resource.Giga), spec.StorageClassName)
+
+	defaultGenericClusteredResource(&spec.VerneMQ.AstarteGenericClusteredResource)
+	if spec.VerneMQ.Port == nil {
+		spec.VerneMQ.Port = pointy.Int16(8883)
+	}
+	spec.VerneMQ.Storage = defaultPersistentStorage(spec.VerneMQ.Storage, resource.NewScaledQuantity(4, resource.Giga), spec.StorageClassName)
+
+	if spec.CFSSL.Deploy == nil {
+		spec.CFSSL.Deploy = pointy.Bool(true)
+	}
+	spec.CFSSL.Storage = defaultPersistentStorage(spec.CFSSL.Storage, resource.NewScaledQuantity(4, resource.Giga), spec.StorageClassName)
+
+	// Astarte Components
+	components := &spec.Components
+	defaultGenericAPI(&components.Housekeeping.API)
+	defaultGenericClusteredResource(&components.Housekeeping.Backend)
+	defaultGenericAPI(&components.RealmManagement.API)
+	defaultGenericClusteredResource(&components.RealmManagement.Backend)
+	defaultGenericAPI(&components.Pairing.API)
+	defaultGenericClusteredResource(&components.Pairing.Backend)
+	defaultGenericClusteredResource(&components.TriggerEngine)
+
+	defaultGenericClusteredResource(&components.DataUpdaterPlant.AstarteGenericClusteredResource)
+	if components.DataUpdaterPlant.DataQueueCount == nil {
+		components.DataUpdaterPlant.DataQueueCount = pointy.Int(128)
+	}
+
+	defaultGenericAPI(&components.AppengineAPI.AstarteGenericAPISpec)
+	if components.AppengineAPI.MaxResultsLimit == nil {
+		components.AppengineAPI.MaxResultsLimit = pointy.Int(10000)
+	}
+
+	defaultGenericClusteredResource(&components.Dashboard.AstarteGenericClusteredResource)
+	if components.Dashboard.SSL == nil {
+		components.Dashboard.SSL = pointy.Bool(true)
+	}
+}
+
+func defaultGenericClusteredResource(resource *AstarteGenericClusteredResource) {
+	if resource.Deploy == nil {
+		resource.Deploy = pointy.Bool(true)
+	}
+	if resource.Replicas == nil {
+		resource.Replicas = pointy.Int32(1)
+	}
+	if resource.AntiAffinity == nil {
+		resource.AntiAffinity = pointy.Bool(true)
+	}
+}
+
+func defaultGenericAPI(api *AstarteGenericAPISpec) {
+	defaultGenericClusteredResource(&api.AstarteGenericClusteredResource)
+	if api.DisableAuthentication == nil {
+		api.DisableAuthentication = pointy.Bool(false)
+	}
+}
+
+func defaultPersistentStorage(storage *AstartePersistentStorageSpec, size *resource.Quantity, storageClassName string) *AstartePersistentStorageSpec {
+	if storage == nil {
+		// The global StorageClassName is honored only when a storage section exists: materializing one would
+		// change the resulting PersistentVolumeClaim, which can't be updated in a StatefulSet.
+		if storageClassName != "" {
+			return nil
+		}
+		storage = &AstartePersistentStorageSpec{}
+	}
+	if storage.VolumeDefinition == nil && storage.Size == nil {
+		storage.Size = size
+	}
+	return storage
+}
@@ -0,0 +1,625 @@
+package v1alpha2
+
+import (
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+const (
+	// PausedAnnotation pauses the reconciliation of an Astarte Resource when set to "true"
+	PausedAnnotation = "api.astarte-platform.org/paused"
+	// PlanAnnotation, when set to "true", makes the Operator compute the changes it would apply to the cluster and
+	// report them in the Status, instead of applying them
+	PlanAnnotation = "api.astarte-platform.org/plan"
+	// UnmanagedAnnotation, when set to "true" on a resource owned by an Astarte Resource, prevents the Operator from
+	// updating or deleting it, while the rest of the cluster keeps being reconciled
+	UnmanagedAnnotation = "api.astarte-platform.org/unmanaged"
+)
+
+// ReconciliationPhase describes the reconciliation phase the Resource is in
+type ReconciliationPhase string
+
+const (
+	// ReconciliationPhaseUnknown represents an Unknown Phase of the Resource. When in this state, it might
+	// have never been reconciled
+	ReconciliationPhaseUnknown ReconciliationPhase = ""
+	// ReconciliationPhaseReconciling means the Resource is currently in the process of being reconciled
+	ReconciliationPhaseReconciling ReconciliationPhase = "Reconciling"
+	// ReconciliationPhaseUpgrading means the Resource is currently in the process of being upgraded to a new Astarte version.
+	// When successful, the Resource will transition to ReconciliationPhaseReconciling
+	ReconciliationPhaseUpgrading ReconciliationPhase = "Upgrading"
+	// ReconciliationPhaseReconciled means the Resource is currently reconciled and stable. The resource should stay in this
+	// state for most of the time.
+	ReconciliationPhaseReconciled ReconciliationPhase = "Reconciled"
+	// ReconciliationPhaseFailed means the Resource failed to reconcile. If this state persists, a manual intervention
+	// might be necessary.
+	ReconciliationPhaseFailed ReconciliationPhase = "Failed"
+)
+
+func (p *ReconciliationPhase) String() string {
+	return string(*p)
+}
+
+// AstarteAntiAffinityMode describes how the Pods of a clustered Component are spread across the cluster
+// +kubebuilder:validation:Enum=none;preferred-host;required-host;preferred-zone;required-zone
+type AstarteAntiAffinityMode string
+
+const (
+	// AntiAffinityNone means the Component's Pods can be scheduled anywhere
+	AntiAffinityNone AstarteAntiAffinityMode = "none"
+	// AntiAffinityPreferredHost means the Component's Pods are spread across Nodes when possible
+	AntiAffinityPreferredHost AstarteAntiAffinityMode = "preferred-host"
+	// AntiAffinityRequiredHost means each Node runs at most one of the Component's Pods
+	AntiAffinityRequiredHost AstarteAntiAffinityMode = "required-host"
+	// AntiAffinityPreferredZone means the Component's Pods are spread across zones, and then Nodes, when possible
+	AntiAffinityPreferredZone AstarteAntiAffinityMode = "preferred-zone"
+	// AntiAffinityRequiredZone means each zone runs at most one of the Component's Pods
+	AntiAffinityRequiredZone AstarteAntiAffinityMode = "required-zone"
+)
+
+// AstarteComponent describes an internal Astarte Component
+type AstarteComponent string
+
+const (
+	// AppEngineAPI represents Astarte AppEngine API
+	AppEngineAPI AstarteComponent = "appengine_api"
+	// DataUpdaterPlant represents Astarte Data Updater Plant
+	DataUpdaterPlant AstarteComponent = "data_updater_plant"
+	// Housekeeping represents Astarte Housekeeping
+	Housekeeping AstarteComponent = "housekeeping"
+	// HousekeepingAPI represents Astarte Housekeeping API
+	HousekeepingAPI AstarteComponent = "housekeeping_api"
+	// Pairing represents Astarte Pairing
+	Pairing AstarteComponent = "pairing"
+	// PairingAPI represents Astarte Pairing API
+	PairingAPI AstarteComponent = "pairing_api"
+	// RealmManagement represents Astarte Realm Management
+	RealmManagement AstarteComponent = "realm_management"
+	// RealmManagementAPI represents Astarte Realm Management API
+	RealmManagementAPI AstarteComponent = "realm_management_api"
+	// TriggerEngine represents Astarte Trigger Engine
+	TriggerEngine AstarteComponent = "trigger_engine"
+	// Dashboard represents Astarte Dashboard
+	Dashboard AstarteComponent = "dashboard"
+)
+
+func (a *AstarteComponent) String() string {
+	return string(*a)
+}
+
+// DashedString returns the Astarte Component in a Kubernetes-friendly format,
+// e.g: data-updater-plant instead of data_updater_plant
+func (a *AstarteComponent) DashedString() string {
+	return strings.Replace(a.String(), "_", "-", -1)
+}
+
+// DockerImageName returns the Docker Image name for this Astarte Component
+func (a *AstarteComponent) DockerImageName() string {
+	switch *a {
+	case Dashboard:
+		return "astarte-dashboard"
+	}
+	return "astarte_" + a.String()
+}
+
+// ServiceName returns the Kubernetes Service Name associated to this Astarte component,
+// if any, otherwise returns an empty string.
+// This will return a meaningful value only for API components or the Dashboard.
+func (a *AstarteComponent) ServiceName() string {
+	if !strings.Contains(a.String(), "api") && a.String() != "dashboard" {
+		return ""
+	}
+	return strings.Replace(a.DashedString(), "-api", "", -1)
+}
+
+// AstartePodSchedulingSpec defines where the Pods of a Component are scheduled, and how they are labelled and annotated
+type AstartePodSchedulingSpec struct {
+	// Constrains the Component's Pods to Nodes having these labels
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// +optional
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// +optional
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// Additional labels for the Component's Pods. Labels set by the Operator can't be overridden.
+	// +optional
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+	// Additional annotations for the Component's Pods
+	// +optional
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+}
+
+// AstartePodExtensionsSpec defines additional settings and containers for the Pods of a Component. They are merged
+// with the ones generated by the Operator: entries with the same name replace generated ones in place, while new
+// entries are appended in the order they are specified.
+type AstartePodExtensionsSpec struct {
+	// Additional environment variables for the Component's main container
+	// +optional
+	AdditionalEnv []v1.EnvVar `json:"additionalEnv,omitempty"`
+	// Additional volumes for the Component's Pods
+	// +optional
+	AdditionalVolumes []v1.Volume `json:"additionalVolumes,omitempty"`
+	// Additional volume mounts for the Component's main container. Mounts are matched on their mount path.
+	// +optional
+	AdditionalVolumeMounts []v1.VolumeMount `json:"additionalVolumeMounts,omitempty"`
+	// Init containers to run before the Component's main container
+	// +optional
+	InitContainers []v1.Container `json:"initContainers,omitempty"`
+	// Additional containers to run alongside the Component's main container
+	// +optional
+	Sidecars []v1.Container `json:"sidecars,omitempty"`
+}
+
+// AstartePodSecuritySpec defines the security settings of the Pods of a Component. Astarte services can opt into
+// hardened defaults, which are replaced entirely by any security context specified here.
+type AstartePodSecuritySpec struct {
+	// Run Astarte services as an unprivileged user without any capability, with the runtime's default seccomp profile
+	// and a writable directory for the release's runtime configuration. Requires images supporting it, and only
+	// applies to the API and backend services. Defaults to false.
+	// +optional
+	Hardened *bool `json:"hardened,omitempty"`
+	// Security settings for the Component's Pods, e.g. runAsNonRoot, runAsUser or fsGroup
+	// +optional
+	PodSecurityContext *v1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// Security settings for the Component's main container, e.g. readOnlyRootFilesystem or capabilities
+	// +optional
+	SecurityContext *v1.SecurityContext `json:"securityContext,omitempty"`
+	// The seccomp profile of the Component's Pods, e.g. runtime/default. Use unconfined to disable the default
+	// profile Astarte services run with.
+	// +optional
+	SeccompProfile string `json:"seccompProfile,omitempty"`
+}
+
+// AstartePodDisruptionBudgetSpec defines how many Pods of a Component can be evicted at the same time by voluntary
+// disruptions, e.g. Node drains. Only one of minAvailable and maxUnavailable can be specified.
+type AstartePodDisruptionBudgetSpec struct {
+	// +optional
+	/// +kubebuilder:default=true
+	Enabled *bool `json:"enabled,omitempty"`
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// AstarteAutoscalingSpec defines how a Component is scaled horizontally. When autoscaling is enabled, the Component's
+// replicas are managed by a HorizontalPodAutoscaler and the replicas field is ignored.
+type AstarteAutoscalingSpec struct {
+	// +optional
+	/// +kubebuilder:default=false
+	Enabled *bool `json:"enabled,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+	// Average CPU utilization across the Component's Pods, as a percentage of their requested CPU
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+	// Average memory utilization across the Component's Pods, as a percentage of their requested memory
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+}
+
+// AstarteGenericClusteredResource defines the common settings of all clustered Astarte Components and Dependencies
+type AstarteGenericClusteredResource struct {
+	// +optional
+	/// +kubebuilder:default=true
+	Deploy *bool `json:"deploy,omitempty"`
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// +optional
+	/// +kubebuilder:default=true
+	AntiAffinity *bool `json:"antiAffinity,omitempty"`
+	// How the Component's Pods are spread across the cluster. When specified, it takes precedence over antiAffinity,
+	// which is equivalent to required-host when true and to none when false.
+	// +optional
+	AntiAffinityMode AstarteAntiAffinityMode `json:"antiAffinityMode,omitempty"`
+	// Replaces the affinity generated by the Operator altogether
+	// +optional
+	CustomAffinity *v1.Affinity `json:"customAffinity,omitempty"`
+	// +optional
+	Version string `json:"version,omitempty"`
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Compute Resources for this Component.
+	// +optional
+	Resources v1.ResourceRequirements `json:"resources,omitempty"`
+	// When not specified, Components running more than one replica can have at most one of them disrupted at a time.
+	// +optional
+	PodDisruptionBudget *AstartePodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+
+	AstartePodSchedulingSpec `json:",inline"`
+	AstartePodExtensionsSpec `json:",inline"`
+	AstartePodSecuritySpec   `json:",inline"`
+}
+
+// AstarteGenericAPISpec represents a generic Astarte API Component in the Deployment spec
+type AstarteGenericAPISpec struct {
+	AstarteGenericClusteredResource `json:",inline"`
+	// +optional
+	DisableAuthentication *bool `json:"disableAuthentication,omitempty"`
+	// +optional
+	Autoscaling *AstarteAutoscalingSpec `json:"autoscaling,omitempty"`
+}
+
+type AstartePersistentStorageSpec struct {
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+	// +optional
+	ClassName string `json:"className,omitempty"`
+	// +optional
+	VolumeDefinition *v1.Volume `json:"volumeDefinition,omitempty"`
+}
+
+type AstarteAPISpec struct {
+	// +optional
+	SSL  *bool  `json:"ssl,omitempty"`
+	Host string `json:"host"`
+}
+
+type AstarteRabbitMQConnectionSecretSpec struct {
+	Name        string `json:"name"`
+	UsernameKey string `json:"usernameKey"`
+	PasswordKey string `json:"passwordKey"`
+}
+
+type AstarteRabbitMQConnectionSpec struct {
+	Host string `json:"host"`
+	// +optional
+	Port *int16 `json:"port,omitempty"`
+	// +optional
+	Username string `json:"username,omitempty"`
+	// +optional
+	Password string `json:"password,omitempty"`
+	// +optional
+	Secret *AstarteRabbitMQConnectionSecretSpec `json:"secret,omitempty"`
+}
+
+type AstarteRabbitMQSpec struct {
+	AstarteGenericClusteredResource `json:",inline"`
+	// +optional
+	Connection *AstarteRabbitMQConnectionSpec `json:"connection,omitempty"`
+	// +optional
+	/// +kubebuilder:default={"size": "4Gi"}
+	Storage *AstartePersistentStorageSpec `json:"storage,omitempty"`
+	// +optional
+	AdditionalPlugins []string `json:"additionalPlugins,omitempty"`
+}
+
+type AstarteCassandraSpec struct {
+	AstarteGenericClusteredResource `json:",inline"`
+	// +optional
+	Nodes string `json:"nodes,omitempty"`
+	// +optional
+	MaxHeapSize string `json:"maxHeapSize,omitempty"`
+	// +optional
+	HeapNewSize string `json:"heapNewSize,omitempty"`
+	// +optional
+	Storage *AstartePersistentStorageSpec `json:"storage,omitempty"`
+}
+
+type AstarteVerneMQSpec struct {
+	AstarteGenericClusteredResource `json:",inline"`
+	Host                            string `json:"host"`
+	// +optional
+	Port *int16 `json:"port,omitempty"`
+	// +optional
+	CaSecret string `json:"caSecret,omitempty"`
+	// +optional
+	Storage *AstartePersistentStorageSpec `json:"storage,omitempty"`
+}
+
+type AstarteGenericComponentSpec struct {
+	// +optional
+	API AstarteGenericAPISpec `json:"api,omitempty"`
+	// +optional
+	Backend AstarteGenericClusteredResource `json:"backend,omitempty"`
+}
+
+type AstarteDataUpdaterPlantSpec struct {
+	AstarteGenericClusteredResource `json:",inline"`
+	// +optional
+	DataQueueCount *int `json:"dataQueueCount,omitempty"`
+}
+
+type AstarteAppengineAPISpec struct {
+	AstarteGenericAPISpec `json:",inline"`
+	// +kubebuilder:validation:Minimum=100
+	// +optional
+	MaxResultsLimit *int `json:"maxResultsLimit,omitempty"`
+}
+
+type AstarteDashboardConfigAuthSpec struct {
+	Type string `json:"type"`
+}
+
+type AstarteDashboardConfigSpec struct {
+	// +optional
+	RealmManagementAPIURL string `json:"realmManagementApiUrl,omitempty"`
+	// +optional
+	DefaultRealm string `json:"defaultRealm,omitempty"`
+	// +optional
+	DefaultAuth string `json:"defaultAuth,omitempty"`
+	// +optional
+	Auth []AstarteDashboardConfigAuthSpec `json:"auth,omitempty"`
+}
+
+type AstarteDashboardSpec struct {
+	AstarteGenericClusteredResource `json:",inline"`
+	// +optional
+	SSL *bool `json:"ssl,omitempty"`
+	// +optional
+	Host string `json:"host,omitempty"`
+	// +optional
+	Config AstarteDashboardConfigSpec `json:"config,omitempty"`
+	// +optional
+	Autoscaling *AstarteAutoscalingSpec `json:"autoscaling,omitempty"`
+}
+
+type AstarteComponentsSpec struct {
+	// Compute Resources for this Component.
+	// +optional
+	Resources v1.ResourceRequirements `json:"resources,omitempty"`
+	// +optional
+	Housekeeping AstarteGenericComponentSpec `json:"housekeeping,omitempty"`
+	// +optional
+	RealmManagement AstarteGenericComponentSpec `json:"realmManagement,omitempty"`
+	// +optional
+	Pairing AstarteGenericComponentSpec `json:"pairing,omitempty"`
+	// +optional
+	DataUpdaterPlant AstarteDataUpdaterPlantSpec `json:"dataUpdaterPlant,omitempty"`
+	// +optional
+	AppengineAPI AstarteAppengineAPISpec `json:"appengineApi,omitempty"`
+	// +optional
+	TriggerEngine AstarteGenericClusteredResource `json:"triggerEngine,omitempty"`
+	// +optional
+	Dashboard AstarteDashboardSpec `json:"dashboard,omitempty"`
+}
+
+type AstarteCFSSLDBConfigSpec struct {
+	Driver     string `json:"driver,omitempty"`
+	DataSource string `json:"dataSource,omitempty"`
+}
+
+type AstarteCFSSLCSRRootCAKeySpec struct {
+	Algo string `json:"algo"`
+	Size int    `json:"size"`
+}
+
+type AstarteCFSSLCSRRootCANamesSpec struct {
+	C  string `json:"C"`
+	L  string `json:"L"`
+	O  string `json:"O"`
+	OU string `json:"OU"`
+	ST string `json:"ST"`
+}
+
+type AstarteCFSSLCSRRootCACASpec struct {
+	Expiry string `json:"expiry"`
+}
+
+type AstarteCFSSLCSRRootCASpec struct {
+	CN    string                           `json:"CN"`
+	Key   *AstarteCFSSLCSRRootCAKeySpec    `json:"key"`
+	Names []AstarteCFSSLCSRRootCANamesSpec `json:"names"`
+	CA    *AstarteCFSSLCSRRootCACASpec     `json:"ca"`
+}
+
+type AstarteCFSSLCARootConfigSigningCAConstraintSpec struct {
+	IsCA           bool `json:"is_ca"`
+	MaxPathLen     int  `json:"max_path_len"`
+	MaxPathLenZero bool `json:"max_path_len_zero"`
+}
+
+type AstarteCFSSLCARootConfigSigningDefaultSpec struct {
+	Usages       []string                                         `json:"usages"`
+	Expiry       string                                           `json:"expiry"`
+	CAConstraint *AstarteCFSSLCARootConfigSigningCAConstraintSpec `json:"ca_constraint"`
+}
+
+type AstarteCFSSLCARootConfigSigningSpec struct {
+	Default *AstarteCFSSLCARootConfigSigningDefaultSpec `json:"default"`
+}
+
+type AstarteCFSSLCARootConfigSpec struct {
+	Signing *AstarteCFSSLCARootConfigSigningSpec `json:"signing"`
+}
+
+type AstarteCFSSLSpec struct {
+	// +optional
+	Deploy *bool `json:"deploy,omitempty"`
+	// +optional
+	URL string `json:"url,omitempty"`
+	// +optional
+	CaExpiry string `json:"caExpiry,omitempty"`
+	// +optional
+	CertificateExpiry string `json:"certificateExpiry,omitempty"`
+	// +optional
+	DBConfig *AstarteCFSSLDBConfigSpec `json:"dbConfig,omitempty"`
+	// Compute Resources for this Component.
+	// +optional
+	Resources v1.ResourceRequirements `json:"resources,omitempty"`
+	// +optional
+	Version string `json:"version,omitempty"`
+	// +optional
+	Image string `json:"image,omitempty"`
+	// +optional
+	Storage *AstartePersistentStorageSpec `json:"storage,omitempty"`
+	// +optional
+	CSRRootCa *AstarteCFSSLCSRRootCASpec `json:"csrRootCa,omitempty"`
+	// +optional
+	CARootConfig *AstarteCFSSLCARootConfigSpec `json:"caRootConfig,omitempty"`
+
+	AstartePodSchedulingSpec `json:",inline"`
+	AstartePodExtensionsSpec `json:",inline"`
+	AstartePodSecuritySpec   `json:",inline"`
+}
+
+// AstarteSpec defines the desired state of Astarte
+type AstarteSpec struct {
+	// The Astarte Version for this Resource
+	Version string `json:"version"`
+	// +optional
+	ImagePullPolicy *v1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// +optional
+	ImagePullSecrets []v1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// +optional
+	DistributionChannel string `json:"distributionChannel,omitempty"`
+	// +optional
+	DeploymentStrategy appsv1.DeploymentStrategy `json:"deploymentStrategy,omitempty"`
+	// +optional
+	/// +kubebuilder:default=true
+	RBAC *bool `json:"rbac,omitempty"`
+	// +optional
+	StorageClassName string         `json:"storageClassName,omitempty"`
+	API              AstarteAPISpec `json:"api"`
+	// +optional
+	/// +kubebuilder:default={"deploy":true}
+	RabbitMQ AstarteRabbitMQSpec `json:"rabbitmq"`
+	// +optional
+	Cassandra AstarteCassandraSpec `json:"cassandra"`
+	VerneMQ   AstarteVerneMQSpec   `json:"vernemq"`
+	// +optional
+	CFSSL AstarteCFSSLSpec `json:"cfssl"`
+	// +optional
+	Components AstarteComponentsSpec `json:"components"`
+	// When true, the Operator stops changing the cluster and only reports its status, e.g. to hand-edit resources
+	// during maintenance. The same can be achieved by setting the api.astarte-platform.org/paused annotation to "true".
+	// +optional
+	Paused *bool `json:"paused,omitempty"`
+}
+
+// AstartePlannedAction is the action the Operator would take on an object
+type AstartePlannedAction string
+
+const (
+	// PlannedActionCreate means the object would be created
+	PlannedActionCreate AstartePlannedAction = "Create"
+	// PlannedActionUpdate means the object would be updated
+	PlannedActionUpdate AstartePlannedAction = "Update"
+	// PlannedActionDelete means the object would be deleted
+	PlannedActionDelete AstartePlannedAction = "Delete"
+)
+
+// AstartePlannedChange describes a change the Operator would apply to an object
+type AstartePlannedChange struct {
+	Action AstartePlannedAction `json:"action"`
+	Kind   string               `json:"kind"`
+	Name   string               `json:"name"`
+	// Whether the change would roll the Pods of a Deployment or StatefulSet
+	// +optional
+	RollsPods bool `json:"rollsPods,omitempty"`
+	// The error the API Server would return when applying the change, if any
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// AstartePlan lists all changes the Operator would apply to reconcile the Spec, without applying them
+type AstartePlan struct {
+	// The Resource's generation the Plan was computed from
+	ObservedGeneration int64 `json:"observedGeneration"`
+	// When the Plan was computed
+	GeneratedAt metav1.Time `json:"generatedAt"`
+	// The changes which would be applied, validated by the API Server through a dry run
+	// +optional
+	Changes []AstartePlannedChange `json:"changes,omitempty"`
+	// Operations which can't be planned, as they'd be run outside of a dry run, e.g. upgrades
+	// +optional
+	Notes []string `json:"notes,omitempty"`
+}
+
+// AstarteComponentStatus describes the observed state of an Astarte Component or Dependency
+type AstarteComponentStatus struct {
+	// Name of the Component or Dependency, e.g. housekeeping_api or rabbitmq
+	Name string `json:"name"`
+	// Whether the Operator is requested to deploy the Component
+	Deployed bool `json:"deployed"`
+	// Whether the Component has enough ready replicas to serve requests
+	Ready bool `json:"ready"`
+	// The Dependencies or Components which must become ready before this Component is reconciled. A Component
+	// waiting for any of them is not brought up to date.
+	// +optional
+	WaitingFor []string `json:"waitingFor,omitempty"`
+	// +optional
+	DesiredReplicas int32 `json:"desiredReplicas,omitempty"`
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	// The image the Component is running
+	// +optional
+	Image string `json:"image,omitempty"`
+	// The version of the Component, as inferred from its image tag
+	// +optional
+	Version string `json:"version,omitempty"`
+	// Last time the Component was deployed, removed, or changed its readiness
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// AstarteStatus defines the observed state of Astarte
+type AstarteStatus struct {
+	ReconciliationPhase ReconciliationPhase `json:"phase"`
+	AstarteVersion      string              `json:"astarteVersion"`
+	OperatorVersion     string              `json:"operatorVersion"`
+	Health              string              `json:"health"`
+	BaseAPIURL          string              `json:"baseAPIURL"`
+	BrokerURL           string              `json:"brokerURL"`
+	// Conditions represent the latest available observations of the Astarte Resource's state
+	// +optional
+	Conditions []AstarteCondition `json:"conditions,omitempty"`
+	// Components reports the state of every Astarte Component and Dependency
+	// +optional
+	Components []AstarteComponentStatus `json:"components,omitempty"`
+	// Plan reports the changes the Operator would apply, when requested through the PlanAnnotation
+	// +optional
+	Plan *AstartePlan `json:"plan,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Astarte is the Schema for the astartes API
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=astartes,scope=Namespaced
+// +kubebuilder:storageversion
+type Astarte struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AstarteSpec   `json:"spec,omitempty"`
+	Status AstarteStatus `json:"status,omitempty"`
+}
+
+// IsPaused returns whether the Operator was requested to stop changing the cluster, either through the Spec or
+// through the PausedAnnotation
+func (a *Astarte) IsPaused() bool {
+	return (a.Spec.Paused != nil && *a.Spec.Paused) || a.GetAnnotations()[PausedAnnotation] == "true"
+}
+
+// IsPlanning returns whether the Operator was requested to only plan changes to the cluster through the PlanAnnotation
+func (a *Astarte) IsPlanning() bool {
+	return a.GetAnnotations()[PlanAnnotation] == "true"
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AstarteList contains a list of Astarte
+type AstarteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Astarte `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Astarte{}, &AstarteList{})
+}
@@ -0,0 +1,188 @@
+package v1alpha2
+
+import (
+	"fmt"
+	"reflect"
+
+	semver "github.com/Masterminds/semver/v3"
+	"github.com/astarte-platform/astarte-kubernetes-operator/version"
+	"github.com/openlyinc/pointy"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateCreate validates a new Astarte resource, making sure its Spec can be handled by this Operator
+func (r *Astarte) ValidateCreate() error {
+	return r.toInvalidError(r.validateSpec())
+}
+
+// ValidateUpdate validates an update to an existing Astarte resource. Besides the checks performed on creation,
+// it refuses upgrades requested while the cluster isn't reporting a stable Health.
+func (r *Astarte) ValidateUpdate(old runtime.Object) error {
+	oldAstarte, ok := old.(*Astarte)
+	if !ok {
+		return fmt.Errorf("Expected an Astarte resource, got %T", old)
+	}
+
+	// Updates which do not touch the Spec (e.g.: finalizers, labels) are always accepted, and so are updates to
+	// resources being deleted, or we might end up preventing the deletion of an Astarte resource which was created
+	// before validation was in place.
+	if reflect.DeepEqual(r.Spec, oldAstarte.Spec) || r.GetDeletionTimestamp() != nil {
+		return nil
+	}
+
+	allErrs := r.validateSpec()
+	allErrs = append(allErrs, r.validateUpgrade(oldAstarte)...)
+	return r.toInvalidError(allErrs)
+}
+
+func (r *Astarte) toInvalidError(allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(SchemeGroupVersion.WithKind("Astarte").GroupKind(), r.Name, allErrs)
+}
+
+func (r *Astarte) validateSpec() field.ErrorList {
+	specPath := field.NewPath("spec")
+	allErrs := validateAstarteVersion(r.Spec.Version, specPath.Child("version"))
+	allErrs = append(allErrs, validateRabbitMQSpec(r.Spec.RabbitMQ, specPath.Child("rabbitmq"))...)
+	allErrs = append(allErrs, validateCassandraSpec(r.Spec.Cassandra, specPath.Child("cassandra"))...)
+	allErrs = append(allErrs, validateCFSSLSpec(r.Spec.CFSSL, specPath.Child("cfssl"))...)
+
+	// Disruption Budgets are validated in the same order Components are reconciled
+	componentsPath := specPath.Child("components")
+	clusteredResources := []struct {
+		fldPath  *field.Path
+		resource AstarteGenericClusteredResource
+	}{
+		{specPath.Child("rabbitmq"), r.Spec.RabbitMQ.AstarteGenericClusteredResource},
+		{specPath.Child("cassandra"), r.Spec.Cassandra.AstarteGenericClusteredResource},
+		{componentsPath.Child("housekeeping", "backend"), r.Spec.Components.Housekeeping.Backend},
+		{componentsPath.Child("housekeeping", "api"), r.Spec.Components.Housekeeping.API.AstarteGenericClusteredResource},
+		{componentsPath.Child("realmManagement", "backend"), r.Spec.Components.RealmManagement.Backend},
+		{componentsPath.Child("realmManagement", "api"), r.Spec.Components.RealmManagement.API.AstarteGenericClusteredResource},
+		{componentsPath.Child("pairing", "backend"), r.Spec.Components.Pairing.Backend},
+		{componentsPath.Child("pairing", "api"), r.Spec.Components.Pairing.API.AstarteGenericClusteredResource},
+		{componentsPath.Child("triggerEngine"), r.Spec.Components.TriggerEngine},
+		{componentsPath.Child("dataUpdaterPlant"), r.Spec.Components.DataUpdaterPlant.AstarteGenericClusteredResource},
+		{componentsPath.Child("appengineApi"), r.Spec.Components.AppengineAPI.AstarteGenericClusteredResource},
+		{specPath.Child("vernemq"), r.Spec.VerneMQ.AstarteGenericClusteredResource},
+		{componentsPath.Child("dashboard"), r.Spec.Components.Dashboard.AstarteGenericClusteredResource},
+	}
+	for _, cr := range clusteredResources {
+		allErrs = append(allErrs, validatePodDisruptionBudgetSpec(cr.resource.PodDisruptionBudget, cr.fldPath.Child("podDisruptionBudget"))...)
+	}
+
+	allErrs = append(allErrs, validateAutoscalingSpec(r.Spec.Components.Housekeeping.API.Autoscaling,
+		componentsPath.Child("housekeeping", "api", "autoscaling"))...)
+	allErrs = append(allErrs, validateAutoscalingSpec(r.Spec.Components.RealmManagement.API.Autoscaling,
+		componentsPath.Child("realmManagement", "api", "autoscaling"))...)
+	allErrs = append(allErrs, validateAutoscalingSpec(r.Spec.Components.Pairing.API.Autoscaling,
+		componentsPath.Child("pairing", "api", "autoscaling"))...)
+	allErrs = append(allErrs, validateAutoscalingSpec(r.Spec.Components.AppengineAPI.Autoscaling,
+		componentsPath.Child("appengineApi", "autoscaling"))...)
+	allErrs = append(allErrs, validateAutoscalingSpec(r.Spec.Components.Dashboard.Autoscaling,
+		componentsPath.Child("dashboard", "autoscaling"))...)
+
+	return allErrs
+}
+
+func (r *Astarte) validateUpgrade(old *Astarte) field.ErrorList {
+	// An upgrade is triggered when the requested version differs from the one reported in the Status. Going back
+	// to the running version is always allowed, as it is the way out of a refused upgrade.
+	if r.Spec.Version == old.Spec.Version || r.Spec.Version == old.Status.AstarteVersion ||
+		old.Status.AstarteVersion == "" || old.Status.AstarteVersion == "snapshot" {
+		return nil
+	}
+
+	if old.Status.Health != "green" {
+		return field.ErrorList{field.Forbidden(field.NewPath("spec", "version"),
+			fmt.Sprintf("upgrading from %s to %s was requested, but the cluster is reporting %q Health. Please wait for the cluster to settle before upgrading",
+				old.Status.AstarteVersion, r.Spec.Version, old.Status.Health))}
+	}
+
+	return nil
+}
+
+func validateAstarteVersion(astarteVersion string, fldPath *field.Path) field.ErrorList {
+	semVersion, err := semver.NewVersion(astarteVersion)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, astarteVersion, fmt.Sprintf("is not a valid Semantic Version: %v", err))}
+	}
+
+	// Constraints do not work with pre-releases, strip it before checking
+	checkVersion, _ := semVersion.SetPrerelease("")
+	constraint, err := semver.NewConstraint(version.AstarteVersionConstraintString)
+	if err != nil {
+		return field.ErrorList{field.InternalError(fldPath, err)}
+	}
+	if !constraint.Check(&checkVersion) {
+		return field.ErrorList{field.Invalid(fldPath, astarteVersion,
+			fmt.Sprintf("is not supported by this Operator, which supports versions respecting this constraint: %s",
+				version.AstarteVersionConstraintString))}
+	}
+
+	return nil
+}
+
+func validateRabbitMQSpec(rmq AstarteRabbitMQSpec, fldPath *field.Path) field.ErrorList {
+	if pointy.BoolValue(rmq.Deploy, true) {
+		return nil
+	}
+
+	// We need to make sure that we have all needed components
+	connectionPath := fldPath.Child("connection")
+	if rmq.Connection == nil {
+		return field.ErrorList{field.Required(connectionPath, "must be specified when not deploying RabbitMQ")}
+	}
+
+	allErrs := field.ErrorList{}
+	if rmq.Connection.Host == "" {
+		allErrs = append(allErrs, field.Required(connectionPath.Child("host"), "must be specified when not deploying RabbitMQ"))
+	}
+	if (rmq.Connection.Username == "" || rmq.Connection.Password == "") && rmq.Connection.Secret == nil {
+		allErrs = append(allErrs, field.Required(connectionPath,
+			"either a username/password combination or a Kubernetes secret must be provided when not deploying RabbitMQ"))
+	}
+
+	return allErrs
+}
+
+func validateCassandraSpec(cassandra AstarteCassandraSpec, fldPath *field.Path) field.ErrorList {
+	if !pointy.BoolValue(cassandra.Deploy, true) && cassandra.Nodes == "" {
+		return field.ErrorList{field.Required(fldPath.Child("nodes"), "must be specified when not deploying Cassandra")}
+	}
+	return nil
+}
+
+func validateCFSSLSpec(cfssl AstarteCFSSLSpec, fldPath *field.Path) field.ErrorList {
+	if !pointy.BoolValue(cfssl.Deploy, true) && cfssl.URL == "" {
+		return field.ErrorList{field.Required(fldPath.Child("url"), "must be specified when not deploying CFSSL")}
+	}
+	return nil
+}
+
+func validatePodDisruptionBudgetSpec(pdb *AstartePodDisruptionBudgetSpec, fldPath *field.Path) field.ErrorList {
+	if pdb != nil && pdb.MinAvailable != nil && pdb.MaxUnavailable != nil {
+		return field.ErrorList{field.Forbidden(fldPath.Child("maxUnavailable"), "cannot be specified together with minAvailable")}
+	}
+	return nil
+}
+
+func validateAutoscalingSpec(autoscaling *AstarteAutoscalingSpec, fldPath *field.Path) field.ErrorList {
+	if autoscaling == nil || !pointy.BoolValue(autoscaling.Enabled, false) {
+		return nil
+	}
+
+	allErrs := field.ErrorList{}
+	if autoscaling.MinReplicas != nil && *autoscaling.MinReplicas > autoscaling.MaxReplicas {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxReplicas"), autoscaling.MaxReplicas, "must be greater than or equal to minReplicas"))
+	}
+	if autoscaling.TargetCPUUtilizationPercentage == nil && autoscaling.TargetMemoryUtilizationPercentage == nil {
+		allErrs = append(allErrs, field.Required(fldPath,
+			"at least one of targetCPUUtilizationPercentage and targetMemoryUtilizationPercentage must be specified when autoscaling is enabled"))
+	}
+	return allErrs
+}
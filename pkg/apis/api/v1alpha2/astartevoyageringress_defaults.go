@@ -0,0 +1,43 @@
+package v1alpha2
+
+import (
+	"github.com/openlyinc/pointy"
+)
+
+// Default materializes in the Spec all the defaults the Operator would otherwise apply while reconciling
+func (r *AstarteVoyagerIngress) Default() {
+	spec := &r.Spec
+
+	defaultGenericIngressSpec(&spec.API.AstarteGenericIngressSpec)
+	if spec.API.Cors == nil {
+		spec.API.Cors = pointy.Bool(false)
+	}
+
+	if spec.Dashboard.SSL == nil {
+		spec.Dashboard.SSL = pointy.Bool(true)
+	}
+
+	defaultGenericIngressSpec(&spec.Broker.AstarteGenericIngressSpec)
+	if spec.Broker.MaxConnections == nil {
+		spec.Broker.MaxConnections = pointy.Int(10000)
+	}
+
+	if spec.Letsencrypt.Use == nil {
+		spec.Letsencrypt.Use = pointy.Bool(true)
+	}
+	if spec.Letsencrypt.Staging == nil {
+		spec.Letsencrypt.Staging = pointy.Bool(false)
+	}
+	if spec.Letsencrypt.AutoHTTPChallenge == nil {
+		spec.Letsencrypt.AutoHTTPChallenge = pointy.Bool(false)
+	}
+}
+
+func defaultGenericIngressSpec(ingress *AstarteGenericIngressSpec) {
+	if ingress.Deploy == nil {
+		ingress.Deploy = pointy.Bool(true)
+	}
+	if ingress.Replicas == nil {
+		ingress.Replicas = pointy.Int32(1)
+	}
+}
@@ -0,0 +1,173 @@
+package v1alpha2
+
+import (
+	voyager "github.com/astarte-platform/astarte-kubernetes-operator/external/voyager/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// AstarteGenericIngressSpec is a common struct for all Ingresses defined by AstarteVoyagerIngress
+type AstarteGenericIngressSpec struct {
+	// +optional
+	Deploy *bool `json:"deploy,omitempty"`
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// +optional
+	Type string `json:"type,omitempty"`
+	// +optional
+	LoadBalancerIP string `json:"loadBalancerIp,omitempty"`
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// +optional
+	TLSSecret string `json:"tlsSecret,omitempty"`
+	// +optional
+	TLSRef *voyager.LocalTypedReference `json:"tlsRef,omitempty"`
+	// +optional
+	AnnotationsService map[string]string `json:"annotationsService,omitempty"`
+}
+
+// AstarteVoyagerIngressAPISpec defines the specification of the APIs
+type AstarteVoyagerIngressAPISpec struct {
+	AstarteGenericIngressSpec `json:",inline"`
+	// +optional
+	Cors *bool `json:"cors,omitempty"`
+	// +optional
+	ExposeHousekeeping *bool `json:"exposeHousekeeping,omitempty"`
+}
+
+// AstarteVoyagerIngressDashboardSpec defines the specification of the Dashboard
+type AstarteVoyagerIngressDashboardSpec struct {
+	// +optional
+	SSL *bool `json:"ssl,omitempty"`
+	// +optional
+	Host string `json:"host,omitempty"`
+	// +optional
+	TLSSecret string `json:"tlsSecret,omitempty"`
+	// +optional
+	TLSRef *voyager.LocalTypedReference `json:"tlsRef,omitempty"`
+}
+
+// AstarteVoyagerIngressBrokerSpec defines the specification of the Broker
+type AstarteVoyagerIngressBrokerSpec struct {
+	AstarteGenericIngressSpec `json:",inline"`
+	// +optional
+	MaxConnections *int `json:"maxConnections,omitempty"`
+}
+
+// AstarteVoyagerIngressLetsEncryptSpec defines the specification of the Let's Encrypt Integration
+type AstarteVoyagerIngressLetsEncryptSpec struct {
+	// +optional
+	Use *bool `json:"use,omitempty"`
+	// +optional
+	Staging *bool `json:"staging,omitempty"`
+	// +optional
+	AcmeEmail string `json:"acmeEmail,omitempty"`
+	// +optional
+	Domains []string `json:"domains,omitempty"`
+	// +optional
+	AutoHTTPChallenge *bool `json:"autoHTTPChallenge,omitempty"`
+	// +optional
+	ChallengeProvider voyager.ChallengeProvider `json:"challengeProvider,omitempty"`
+}
+
+// AstarteVoyagerIngressSpec defines the desired state of AstarteVoyagerIngress
+type AstarteVoyagerIngressSpec struct {
+	// +optional
+	ImagePullPolicy *v1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	Astarte         string         `json:"astarte"`
+	// +optional
+	API AstarteVoyagerIngressAPISpec `json:"api,omitempty"`
+	// +optional
+	Dashboard AstarteVoyagerIngressDashboardSpec `json:"dashboard,omitempty"`
+	// +optional
+	Broker AstarteVoyagerIngressBrokerSpec `json:"broker,omitempty"`
+	// +optional
+	Letsencrypt AstarteVoyagerIngressLetsEncryptSpec `json:"letsencrypt,omitempty"`
+}
+
+// AstarteVoyagerIngressCertificateState describes the state of the Let's Encrypt Certificate
+type AstarteVoyagerIngressCertificateState string
+
+const (
+	// CertificateStateNotRequested means Let's Encrypt is not in use
+	CertificateStateNotRequested AstarteVoyagerIngressCertificateState = "NotRequested"
+	// CertificateStatePending means the Certificate has been requested, but not issued yet
+	CertificateStatePending AstarteVoyagerIngressCertificateState = "Pending"
+	// CertificateStateIssued means the Certificate has been issued
+	CertificateStateIssued AstarteVoyagerIngressCertificateState = "Issued"
+	// CertificateStateFailed means Voyager failed to obtain the Certificate
+	CertificateStateFailed AstarteVoyagerIngressCertificateState = "Failed"
+	// CertificateStateRateLimited means Let's Encrypt is rate limiting Certificate requests
+	CertificateStateRateLimited AstarteVoyagerIngressCertificateState = "RateLimited"
+)
+
+// AstarteVoyagerIngressLoadBalancerStatus describes the observed state of a Voyager Ingress
+type AstarteVoyagerIngressLoadBalancerStatus struct {
+	// Whether the Ingress is requested to be deployed
+	Deployed bool `json:"deployed"`
+	// Whether the Ingress is ready to serve traffic
+	Ready bool `json:"ready"`
+	// The resolved addresses of the Load Balancer, either IPs or Hostnames
+	// +optional
+	Addresses []string `json:"addresses,omitempty"`
+	// The hosts served by the Ingress
+	// +optional
+	Hosts []string `json:"hosts,omitempty"`
+}
+
+// AstarteVoyagerIngressCertificateStatus describes the observed state of the Let's Encrypt Certificate
+type AstarteVoyagerIngressCertificateStatus struct {
+	State AstarteVoyagerIngressCertificateState `json:"state"`
+	// Details about the Certificate's state, as reported by Voyager
+	// +optional
+	Message string `json:"message,omitempty"`
+	// The domains the Certificate is requested for
+	// +optional
+	Domains []string `json:"domains,omitempty"`
+	// Expiration time of the last issued Certificate
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+	// Whether a HTTP-01 Challenge is being bootstrapped, meaning the Ingresses are served without TLS until the
+	// Certificate is issued
+	// +optional
+	BootstrappingHTTPChallenge bool `json:"bootstrappingHTTPChallenge,omitempty"`
+}
+
+// AstarteVoyagerIngressStatus defines the observed state of AstarteVoyagerIngress
+type AstarteVoyagerIngressStatus struct {
+	// +optional
+	API AstarteVoyagerIngressLoadBalancerStatus `json:"api,omitempty"`
+	// +optional
+	Broker AstarteVoyagerIngressLoadBalancerStatus `json:"broker,omitempty"`
+	// +optional
+	Certificate AstarteVoyagerIngressCertificateStatus `json:"certificate,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AstarteVoyagerIngress is the Schema for the astartevoyageringresses API
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=astartevoyageringresses,scope=Namespaced,shortName=avi
+// +kubebuilder:storageversion
+type AstarteVoyagerIngress struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AstarteVoyagerIngressSpec   `json:"spec,omitempty"`
+	Status AstarteVoyagerIngressStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AstarteVoyagerIngressList contains a list of AstarteVoyagerIngress
+type AstarteVoyagerIngressList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AstarteVoyagerIngress `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AstarteVoyagerIngress{}, &AstarteVoyagerIngressList{})
+}
@@ -0,0 +1,9 @@
+package v1alpha2
+
+// v1alpha2 is the storage version of the API, and the Hub every other version converts from and to.
+
+// Hub marks Astarte as a conversion Hub
+func (*Astarte) Hub() {}
+
+// Hub marks AstarteVoyagerIngress as a conversion Hub
+func (*AstarteVoyagerIngress) Hub() {}
@@ -0,0 +1,4 @@
+// Package v1alpha2 contains API Schema definitions for the api v1alpha2 API group
+// +k8s:deepcopy-gen=package,register
+// +groupName=api.astarte-platform.org
+package v1alpha2
@@ -2,21 +2,29 @@ package astarte
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
 	"strings"
 	"time"
 
 	semver "github.com/Masterminds/semver/v3"
-	apiv1alpha1 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha1"
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
 	recon "github.com/astarte-platform/astarte-kubernetes-operator/pkg/controller/astarte/reconcile"
 	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/controller/astarte/upgrade"
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/metrics"
 	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
 	"github.com/astarte-platform/astarte-kubernetes-operator/version"
+	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -36,46 +44,85 @@ var log = logf.Log.WithName("controller_astarte")
 // Add creates a new Astarte Controller and adds it to the Manager. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
-	return add(mgr, newReconciler(mgr))
+	ctx, err := misc.NewManagerContext(mgr)
+	if err != nil {
+		return err
+	}
+	return add(ctx, mgr, newReconciler(ctx, mgr))
 }
 
 // newReconciler returns a new reconcile.Reconciler
-func newReconciler(mgr manager.Manager) reconcile.Reconciler {
-	return &ReconcileAstarte{client: mgr.GetClient(), scheme: mgr.GetScheme()}
+func newReconciler(ctx context.Context, mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileAstarte{
+		ctx:      ctx,
+		recorder: mgr.GetEventRecorderFor("astarte-operator"),
+		client:   misc.NewTimeoutClient(mgr.GetClient(), misc.GetTimeouts().Operation),
+		scheme:   mgr.GetScheme(),
+	}
 }
 
-// add adds a new Controller to mgr with r as the reconcile.Reconciler
-func add(mgr manager.Manager, r reconcile.Reconciler) error {
+// add adds a new Controller to mgr with r as the reconcile.Reconciler. ctx bounds the lookups of its watches.
+func add(ctx context.Context, mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
 	c, err := controller.New("astarte-controller", mgr, controller.Options{Reconciler: r})
 	if err != nil {
 		return err
 	}
 
-	// Watch for changes to primary resource Astarte
-	if err := c.Watch(&source.Kind{Type: &apiv1alpha1.Astarte{}}, &handler.EnqueueRequestForObject{}); err != nil {
+	// When watching namespaces chosen by a label selector, the whole cluster is watched: drop the events of other
+	// namespaces, and pick up Resources in namespaces as soon as they match
+	inSelectedNamespaces, err := misc.NewNamespaceSelectorPredicate(ctx, mgr.GetClient())
+	if err != nil {
 		return err
 	}
-
-	// Watch for changes to secondary resource Deployments and requeue the owner Astarte
-	if err := c.Watch(&source.Kind{Type: &appsv1.Deployment{}}, &handler.EnqueueRequestForOwner{
-		IsController: true,
-		OwnerType:    &apiv1alpha1.Astarte{},
-	}); err != nil {
+	if err := misc.WatchSelectedNamespaces(ctx, c, mgr.GetClient(), &apiv1alpha2.AstarteList{}); err != nil {
 		return err
 	}
-	// Watch for changes to secondary resource StatefulSet and requeue the owner Astarte
-	if err := c.Watch(&source.Kind{Type: &appsv1.StatefulSet{}}, &handler.EnqueueRequestForOwner{
-		IsController: true,
-		OwnerType:    &apiv1alpha1.Astarte{},
-	}); err != nil {
+
+	// Watch for changes to primary resource Astarte. Its status is written by the Operator only, and does not need
+	// to be reconciled.
+	if err := c.Watch(&source.Kind{Type: &apiv1alpha2.Astarte{}}, &handler.EnqueueRequestForObject{},
+		misc.IgnoreStatusUpdatesPredicate, inSelectedNamespaces); err != nil {
 		return err
 	}
-	// Watch for changes to secondary resource Job and requeue the owner Astarte
-	if err := c.Watch(&source.Kind{Type: &batchv1.Job{}}, &handler.EnqueueRequestForOwner{
-		IsController: true,
-		OwnerType:    &apiv1alpha1.Astarte{},
-	}); err != nil {
+
+	// Watch for changes to secondary resources Deployment, StatefulSet and Job and requeue the owner Astarte.
+	// Their status is what the Components status is computed from, so every change counts.
+	for _, workload := range []runtime.Object{&appsv1.Deployment{}, &appsv1.StatefulSet{}, &batchv1.Job{}} {
+		if err := c.Watch(&source.Kind{Type: workload}, &handler.EnqueueRequestForOwner{
+			IsController: true,
+			OwnerType:    &apiv1alpha2.Astarte{},
+		}, inSelectedNamespaces); err != nil {
+			return err
+		}
+	}
+
+	// Watch for changes to all other secondary resources and requeue the owner Astarte, so that any drift is
+	// repaired promptly. Status-only updates are just noise here.
+	ownedKinds := []runtime.Object{
+		&v1.Service{},
+		&v1.ConfigMap{},
+		&v1.Secret{},
+		&v1.ServiceAccount{},
+		&rbacv1.Role{},
+		&rbacv1.RoleBinding{},
+		&policyv1beta1.PodDisruptionBudget{},
+		&autoscalingv2beta2.HorizontalPodAutoscaler{},
+	}
+	for _, kind := range ownedKinds {
+		if err := c.Watch(&source.Kind{Type: kind}, &handler.EnqueueRequestForOwner{
+			IsController: true,
+			OwnerType:    &apiv1alpha2.Astarte{},
+		}, misc.IgnoreStatusUpdatesPredicate, inSelectedNamespaces); err != nil {
+			return err
+		}
+	}
+
+	// Watch for changes to Secrets provided by the user, such as RabbitMQ credentials, and requeue the Astarte
+	// Resources consuming them, so that their Pods are rolled out.
+	if err := c.Watch(&source.Kind{Type: &v1.Secret{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: getExternalSecretsMapper(ctx, misc.NewTimeoutClient(mgr.GetClient(), misc.GetTimeouts().Operation)),
+	}, misc.IgnoreStatusUpdatesPredicate, inSelectedNamespaces); err != nil {
 		return err
 	}
 
@@ -91,6 +138,11 @@ type ReconcileAstarte struct {
 	// that reads objects from the cache and writes to the apiserver
 	client client.Client
 	scheme *runtime.Scheme
+	// Reconcile has no context of its own: all reconciliations derive from this one, which is cancelled when the
+	// Manager stops
+	ctx context.Context
+	// recorder emits Events on the reconciled Resources. Each reconciliation carries it in its context.
+	recorder record.EventRecorder
 }
 
 // Reconcile reads that state of the cluster for a Astarte object and makes changes based on the state read
@@ -103,26 +155,31 @@ func (r *ReconcileAstarte) Reconcile(request reconcile.Request) (reconcile.Resul
 	reqLogger.Info("Reconciling Astarte")
 
 	// Fetch the Astarte instance
-	instance := &apiv1alpha1.Astarte{}
-	err := r.client.Get(context.TODO(), request.NamespacedName, instance)
+	instance := &apiv1alpha2.Astarte{}
+	err := r.client.Get(r.ctx, request.NamespacedName, instance)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			metrics.DeleteAstarteStatus(request.Namespace, request.Name)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
 		return reconcile.Result{}, err
 	}
+	// Whatever the outcome, the status the reconciliation ends with is exposed
+	defer metrics.SetAstarteStatus(instance)
+
+	ctx, cancel := context.WithTimeout(misc.WithEventRecorder(r.ctx, r.recorder), getReconcileTimeout(instance))
+	defer cancel()
 
 	// Are we capable of handling the requested version?
 	// Build a SemVer out of the requested Astarte Version in the Spec.
 	newAstarteSemVersion, err := semver.NewVersion(instance.Spec.Version)
 	if err != nil {
-		// Reconcile every minute if we're here
-		return reconcile.Result{RequeueAfter: time.Minute},
-			fmt.Errorf("Could not build a valid Astarte Semantic Version out of requested Astarte Version %v. Refusing to proceed", err)
+		return r.handleReconcileError(instance, recon.NewUnsupportedVersionError(
+			fmt.Errorf("Could not build a valid Astarte Semantic Version out of requested Astarte Version %v. Refusing to proceed", err)), reqLogger)
 	}
 	// Generate another one for checks, as constraints do not work with pre-releases
 	constraintCheckAstarteSemVersion := newAstarteSemVersion
@@ -137,9 +194,9 @@ func (r *ReconcileAstarte) Reconcile(request reconcile.Request) (reconcile.Resul
 		return reconcile.Result{Requeue: false}, err
 	}
 	if !constraint.Check(constraintCheckAstarteSemVersion) {
-		return reconcile.Result{Requeue: false},
+		return r.handleReconcileError(instance, recon.NewUnsupportedVersionError(
 			fmt.Errorf("Astarte version %s is not supported by this Operator! This Operator supports versions respecting this constraint: %s. Please migrate to an Operator supporting this version",
-				instance.Spec.Version, version.AstarteVersionConstraintString)
+				instance.Spec.Version, version.AstarteVersionConstraintString)), reqLogger)
 	}
 
 	// Check if the Astarte instance is marked to be deleted, which is
@@ -149,23 +206,72 @@ func (r *ReconcileAstarte) Reconcile(request reconcile.Request) (reconcile.Resul
 			// Run finalization logic for astarteFinalizer. If the
 			// finalization logic fails, don't remove the finalizer so
 			// that we can retry during the next reconciliation.
-			if err := r.finalizeAstarte(instance); err != nil {
+			if err := r.finalizeAstarte(ctx, instance); err != nil {
 				return reconcile.Result{}, err
 			}
 
 			// Remove astarteFinalizer. Once all finalizers have been
 			// removed, the object will be deleted.
 			instance.SetFinalizers(remove(instance.GetFinalizers(), astarteFinalizer))
-			if err := r.client.Update(context.TODO(), instance); err != nil {
+			if err := r.client.Update(ctx, instance); err != nil {
 				return reconcile.Result{}, err
 			}
 		}
 		return reconcile.Result{}, nil
 	}
 
+	// When planning, changes are computed and reported, but not applied
+	if instance.IsPlanning() {
+		return r.planAstarte(ctx, instance, reqLogger)
+	}
+
+	// When paused, the cluster is left as it is, and only observed
+	if instance.IsPaused() {
+		return r.reconcilePausedAstarte(ctx, instance, reqLogger)
+	}
+	// The Resource might have just been resumed: whatever the outcome of the reconciliation, it's no longer Paused
+	r.setReconcileNotPaused(instance)
+
+	result, err := r.reconcileAstarte(ctx, instance, newAstarteSemVersion, reqLogger)
+	if err != nil {
+		return r.handleReconcileError(instance, err, reqLogger)
+	}
+	return result, nil
+}
+
+// getReconcileTimeout returns the deadline of a reconciliation of cr. Upgrades wait for the cluster to settle, and
+// are granted additional time.
+func getReconcileTimeout(cr *apiv1alpha2.Astarte) time.Duration {
+	timeouts := misc.GetTimeouts()
+	if cr.Status.AstarteVersion != "" && cr.Status.AstarteVersion != "snapshot" && cr.Status.AstarteVersion != cr.Spec.Version {
+		return timeouts.Reconcile + timeouts.Upgrade
+	}
+	return timeouts.Reconcile
+}
+
+// handleReconcileError reports err on the Astarte Resource, and decides when to reconcile it again depending on its
+// class. Only transient errors are returned to the Controller, which retries them with exponential backoff: all other
+// classes need something to change first, and are reconciled again after a fixed delay.
+func (r *ReconcileAstarte) handleReconcileError(instance *apiv1alpha2.Astarte, err error, reqLogger logr.Logger) (reconcile.Result, error) {
+	reconcileErr := recon.ClassifyError(err)
+	// The failure is reported even when the reconciliation ran out of time
+	r.setReconcileFailed(r.ctx, instance, reconcileErr, reqLogger)
+
+	if reconcileErr.Class == recon.ErrorClassTransient {
+		return reconcile.Result{}, err
+	}
+	reqLogger.Error(err, "Reconciliation failed", "Error.Class", reconcileErr.Class,
+		"RequeueAfter", reconcileErr.Class.RequeueAfter())
+	return reconcile.Result{RequeueAfter: reconcileErr.Class.RequeueAfter()}, nil
+}
+
+// reconcileAstarte drives the cluster towards the state requested by an Astarte Resource which is not being deleted
+func (r *ReconcileAstarte) reconcileAstarte(ctx context.Context, instance *apiv1alpha2.Astarte, newAstarteSemVersion *semver.Version, reqLogger logr.Logger) (reconcile.Result, error) {
+	var err error
+
 	// Add finalizer for this CR
 	if !contains(instance.GetFinalizers(), astarteFinalizer) {
-		if err := r.addFinalizer(instance); err != nil {
+		if err := r.addFinalizer(ctx, instance); err != nil {
 			return reconcile.Result{}, err
 		}
 	}
@@ -175,7 +281,7 @@ func (r *ReconcileAstarte) Reconcile(request reconcile.Request) (reconcile.Resul
 		// Ok, in this case there's two potential situations: we're on our first reconcile, or the status is
 		// messed up. Let's see if we can find the Housekeeping Deployment.
 		hkDeployment := &appsv1.Deployment{}
-		err := r.client.Get(context.TODO(),
+		err := r.client.Get(ctx,
 			types.NamespacedName{Name: instance.Name + "-housekeeping", Namespace: instance.Namespace}, hkDeployment)
 		if err == nil {
 			// In this case, we might be in a weird state (e.g.: migrating from the old operator). Let's try and fix this.
@@ -183,14 +289,13 @@ func (r *ReconcileAstarte) Reconcile(request reconcile.Request) (reconcile.Resul
 			hkImage := hkDeployment.Spec.Template.Spec.Containers[0].Image
 			hkImageTokens := strings.Split(hkImage, ":")
 			if len(hkImageTokens) != 2 {
-				// Reconcile every minute if we're here
-				return reconcile.Result{RequeueAfter: time.Minute},
-					fmt.Errorf("Could not parse Astarte version from Housekeeping Image tag %s. Refusing to proceed", hkImage)
+				return reconcile.Result{}, recon.NewUpgradeBlockedError(
+					fmt.Errorf("Could not parse Astarte version from Housekeeping Image tag %s. Refusing to proceed", hkImage))
 			}
 
 			instance.Status.AstarteVersion = hkImageTokens[1]
 			// Update the status
-			if err := r.client.Status().Update(context.TODO(), instance); err != nil {
+			if err := r.client.Status().Update(ctx, instance); err != nil {
 				reqLogger.Error(err, "Failed to update Astarte status.")
 				return reconcile.Result{}, err
 			}
@@ -211,12 +316,10 @@ func (r *ReconcileAstarte) Reconcile(request reconcile.Request) (reconcile.Resul
 		reqLogger.Info("Requested Version and Status Version are different, checking for upgrades...",
 			"Version.Old", instance.Status.AstarteVersion, "Version.New", instance.Spec.Version)
 
-		// TODO: This should probably be put in the Admission Webhook too, going forward
+		// This is enforced by the Validating Webhook too, but it has to be checked here as Webhooks might not be enabled
 		if instance.Status.Health != "green" {
-			reqLogger.Error(fmt.Errorf("Astarte Upgrade requested, but the cluster isn't reporting stable Health. Refusing to upgrade"),
-				"Cluster health is unstable, refusing to upgrade. Please revert to the previous version and wait for the cluster to settle.",
-				"Health", instance.Status.Health)
-			return reconcile.Result{Requeue: false}, fmt.Errorf("Astarte Upgrade requested, but the cluster isn't reporting stable Health. Refusing to upgrade")
+			return reconcile.Result{}, recon.NewUpgradeBlockedError(fmt.Errorf("Astarte Upgrade requested, but the cluster is reporting %s Health. "+
+				"Please revert to the previous version and wait for the cluster to settle", instance.Status.Health))
 		}
 		// We need to check for upgrades.
 		versionString := instance.Status.AstarteVersion
@@ -230,134 +333,88 @@ func (r *ReconcileAstarte) Reconcile(request reconcile.Request) (reconcile.Resul
 		// Build the semantic version
 		oldAstarteSemVersion, err := semver.NewVersion(versionString)
 		if err != nil {
-			// Reconcile every minute if we're here
-			return reconcile.Result{RequeueAfter: time.Minute},
-				fmt.Errorf("Could not build a valid Astarte Semantic Version out of existing Astarte Version %v. Refusing to proceed", err)
+			return reconcile.Result{}, recon.NewUpgradeBlockedError(
+				fmt.Errorf("Could not build a valid Astarte Semantic Version out of existing Astarte Version %v. Refusing to proceed", err))
 		}
 
 		// Ok! Let's try and upgrade (if needed)
-		if err := upgrade.EnsureAstarteUpgrade(oldAstarteSemVersion, newAstarteSemVersion, instance, r.client, r.scheme); err != nil {
-			return reconcile.Result{}, err
+		if err := upgrade.EnsureAstarteUpgrade(ctx, oldAstarteSemVersion, newAstarteSemVersion, instance, r.client, r.scheme); err != nil {
+			// Failures talking to the API Server and interrupted upgrades are retried, any other failure needs a
+			// manual intervention. Upgrade steps wrap the errors they run into.
+			var apiStatus errors.APIStatus
+			if goerrors.As(err, &apiStatus) || ctx.Err() != nil {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{}, recon.NewUpgradeBlockedError(err)
 		}
 	}
 
-	// Start actual reconciliation.
-	// Start by ensuring the housekeeping key
-	if err = recon.EnsureHousekeepingKey(instance, r.client, r.scheme); err != nil {
-		return reconcile.Result{}, err
-	}
-	// Then, make sure we have an up to date Erlang Configuration for our Pods
-	if err = recon.EnsureGenericErlangConfiguration(instance, r.client, r.scheme); err != nil {
-		return reconcile.Result{}, err
-	}
-
-	// Dependencies Dance!
-	// RabbitMQ, first and foremost
-	if err = recon.EnsureRabbitMQ(instance, r.client, r.scheme); err != nil {
-		return reconcile.Result{}, err
-	}
-
-	// Cassandra
-	if err = recon.EnsureCassandra(instance, r.client, r.scheme); err != nil {
-		return reconcile.Result{}, err
-	}
+	// Start actual reconciliation. Components are reconciled following their dependency graph: a failure only
+	// holds back the Components depending on the failed one, while all others are still brought up to date.
+	// Components whose dependencies are not ready yet are held back, as they couldn't possibly work.
+	stepsResult := r.runReconcileSteps(ctx, instance, r.getReconcileSteps(instance), r.client, reqLogger)
 
-	// CFSSL
-	if err = recon.EnsureCFSSL(instance, r.client, r.scheme); err != nil {
+	// Compute overall Readiness for Astarte components and dependencies
+	componentsStatus, err := r.computeComponentsStatus(ctx, instance, stepsResult.waiting)
+	if err != nil {
 		return reconcile.Result{}, err
 	}
-
-	// CFSSL CA Secret
-	if err = recon.EnsureCFSSLCASecret(instance, r.client, r.scheme); err != nil {
-		return reconcile.Result{}, err
+	instance.Status.Components = componentsStatus
+	if stepsResult.err != nil {
+		// The status is updated together with the failure, which is reported for each Component
+		return reconcile.Result{}, stepsResult.err
 	}
 
-	// OK! Now it's time to reconcile all of Astarte Services, in a specific order.
-	// Housekeeping first - it creates/migrates the Database
-	if err = recon.EnsureAstarteGenericBackend(instance, instance.Spec.Components.Housekeeping.Backend, apiv1alpha1.Housekeeping, r.client, r.scheme); err != nil {
-		return reconcile.Result{}, err
-	}
-	// Then Housekeeping API
-	if err = recon.EnsureAstarteGenericAPI(instance, instance.Spec.Components.Housekeeping.API, apiv1alpha1.HousekeepingAPI, r.client, r.scheme); err != nil {
-		return reconcile.Result{}, err
-	}
+	instance.Status.Health = computeHealth(componentsStatus)
 
-	// Then, Realm Management and its API
-	if err = recon.EnsureAstarteGenericBackend(instance, instance.Spec.Components.RealmManagement.Backend, apiv1alpha1.RealmManagement, r.client, r.scheme); err != nil {
-		return reconcile.Result{}, err
-	}
-	if err = recon.EnsureAstarteGenericAPI(instance, instance.Spec.Components.RealmManagement.API, apiv1alpha1.RealmManagementAPI, r.client, r.scheme); err != nil {
-		return reconcile.Result{}, err
-	}
+	r.setReconcileSucceeded(instance)
 
-	// Then, Pairing and its API
-	if err = recon.EnsureAstarteGenericBackend(instance, instance.Spec.Components.Pairing.Backend, apiv1alpha1.Pairing, r.client, r.scheme); err != nil {
-		return reconcile.Result{}, err
-	}
-	if err = recon.EnsureAstarteGenericAPI(instance, instance.Spec.Components.Pairing.API, apiv1alpha1.PairingAPI, r.client, r.scheme); err != nil {
-		return reconcile.Result{}, err
+	// Update status. Components waiting for their dependencies were not applied yet: until they are, the requested
+	// version isn't rolled out, and the Resource is still Reconciling.
+	if len(stepsResult.waiting) == 0 {
+		instance.Status.AstarteVersion = instance.Spec.Version
+		instance.Status.ReconciliationPhase = apiv1alpha2.ReconciliationPhaseReconciled
+	} else {
+		instance.Status.ReconciliationPhase = apiv1alpha2.ReconciliationPhaseReconciling
 	}
+	instance.Status.OperatorVersion = version.Version
+	instance.Status.BaseAPIURL = "https://" + instance.Spec.API.Host
+	instance.Status.BrokerURL = misc.GetVerneMQBrokerURL(instance)
+	// Plans are outdated as soon as changes are applied
+	instance.Status.Plan = nil
 
-	// Trigger Engine right before DUP
-	if err = recon.EnsureAstarteGenericBackend(instance, instance.Spec.Components.TriggerEngine, apiv1alpha1.TriggerEngine, r.client, r.scheme); err != nil {
+	if err := r.client.Status().Update(ctx, instance); err != nil {
+		reqLogger.Error(err, "Failed to update Astarte status.")
 		return reconcile.Result{}, err
 	}
 
-	// Now it's Data Updater plant turn
-	if err = recon.EnsureAstarteGenericBackend(instance, instance.Spec.Components.DataUpdaterPlant.GenericClusteredResource, apiv1alpha1.DataUpdaterPlant, r.client, r.scheme); err != nil {
-		return reconcile.Result{}, err
+	if len(stepsResult.waiting) > 0 {
+		// Requeueing goes through the rate limiter, which backs off exponentially until dependencies are ready
+		reqLogger.Info("Some Components are waiting for their dependencies to become ready, requeueing")
+		return reconcile.Result{Requeue: true}, nil
 	}
 
-	// Now it's AppEngine API turn
-	if err = recon.EnsureAstarteGenericAPI(instance, instance.Spec.Components.AppengineAPI.GenericAPISpec, apiv1alpha1.AppEngineAPI, r.client, r.scheme); err != nil {
-		return reconcile.Result{}, err
-	}
+	reqLogger.Info("Astarte Reconciled successfully")
+	return reconcile.Result{}, nil
+}
 
-	// Last but not least, VerneMQ
-	if err = recon.EnsureVerneMQ(instance, r.client, r.scheme); err != nil {
-		return reconcile.Result{}, err
-	}
+// reconcilePausedAstarte refreshes the status of a paused Astarte Resource, without changing anything in the cluster
+func (r *ReconcileAstarte) reconcilePausedAstarte(ctx context.Context, instance *apiv1alpha2.Astarte, reqLogger logr.Logger) (reconcile.Result, error) {
+	reqLogger.Info("Reconciliation is paused, only updating status")
 
-	// And Dashboard to close it down.
-	if err = recon.EnsureAstarteDashboard(instance, instance.Spec.Components.Dashboard, r.client, r.scheme); err != nil {
+	componentsStatus, err := r.computeComponentsStatus(ctx, instance, nil)
+	if err != nil {
 		return reconcile.Result{}, err
 	}
+	instance.Status.Components = componentsStatus
+	instance.Status.Health = computeHealth(componentsStatus)
+	r.setReconcilePaused(instance)
 
-	// Compute overall Readiness for Astarte deployments
-	astarteDeployments := &appsv1.DeploymentList{}
-	nonReadyDeployments := 0
-	if err := r.client.List(context.TODO(), astarteDeployments, client.InNamespace(instance.Namespace),
-		client.MatchingLabels{"component": "astarte"}); err == nil {
-		for _, deployment := range astarteDeployments.Items {
-			if deployment.Status.ReadyReplicas == 0 {
-				nonReadyDeployments++
-			}
-		}
-	} else {
-		reqLogger.Info("Could not list Astarte deployments to compute health.")
-	}
-
-	if nonReadyDeployments == 0 {
-		instance.Status.Health = "green"
-	} else if nonReadyDeployments == 1 {
-		instance.Status.Health = "yellow"
-	} else {
-		instance.Status.Health = "red"
-	}
-
-	// Update status
-	instance.Status.AstarteVersion = instance.Spec.Version
-	instance.Status.OperatorVersion = version.Version
-	instance.Status.ReconciliationPhase = apiv1alpha1.ReconciliationPhaseReconciled
-	instance.Status.BaseAPIURL = "https://" + instance.Spec.API.Host
-	instance.Status.BrokerURL = misc.GetVerneMQBrokerURL(instance)
-
-	if err := r.client.Status().Update(context.TODO(), instance); err != nil {
+	if err := r.client.Status().Update(ctx, instance); err != nil {
 		reqLogger.Error(err, "Failed to update Astarte status.")
 		return reconcile.Result{}, err
 	}
 
-	reqLogger.Info("Astarte Reconciled successfully")
 	return reconcile.Result{}, nil
 }
 
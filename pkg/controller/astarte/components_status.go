@@ -0,0 +1,164 @@
+package astarte
+
+import (
+	"context"
+	"strings"
+
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
+	"github.com/openlyinc/pointy"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Dependencies are deployed as StatefulSets named after the Astarte instance, e.g. <name>-rabbitmq
+var astarteDependencies = []string{"rabbitmq", "cassandra", "cfssl"}
+
+// VerneMQ is deployed as a StatefulSet too, but it's an Astarte service rather than a Dependency
+const verneMQComponentName = "vernemq"
+
+var astarteComponents = []v1alpha2.AstarteComponent{
+	v1alpha2.Housekeeping,
+	v1alpha2.HousekeepingAPI,
+	v1alpha2.RealmManagement,
+	v1alpha2.RealmManagementAPI,
+	v1alpha2.Pairing,
+	v1alpha2.PairingAPI,
+	v1alpha2.TriggerEngine,
+	v1alpha2.DataUpdaterPlant,
+	v1alpha2.AppEngineAPI,
+	v1alpha2.Dashboard,
+}
+
+// computeComponentsStatus observes every Astarte Component and Dependency, and returns their status, together with the
+// dependencies each of them is waiting for. Last transition times are carried over from the current status whenever
+// a Component didn't change its deployment or readiness.
+func (r *ReconcileAstarte) computeComponentsStatus(ctx context.Context, cr *v1alpha2.Astarte, waiting map[string][]string) ([]v1alpha2.AstarteComponentStatus, error) {
+	statuses := []v1alpha2.AstarteComponentStatus{}
+
+	for _, dependency := range astarteDependencies {
+		status, err := r.getStatefulSetStatus(ctx, cr, dependency, isDependencyDeployed(cr, dependency))
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+
+	for _, component := range astarteComponents {
+		status, err := r.getDeploymentStatus(ctx, cr, component)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+
+	status, err := r.getStatefulSetStatus(ctx, cr, verneMQComponentName, pointy.BoolValue(cr.Spec.VerneMQ.Deploy, true))
+	if err != nil {
+		return nil, err
+	}
+	statuses = append(statuses, status)
+
+	now := metav1.Now()
+	for i := range statuses {
+		statuses[i].WaitingFor = waiting[statuses[i].Name]
+		statuses[i].LastTransitionTime = now
+		for _, old := range cr.Status.Components {
+			if old.Name == statuses[i].Name && old.Deployed == statuses[i].Deployed && old.Ready == statuses[i].Ready {
+				statuses[i].LastTransitionTime = old.LastTransitionTime
+			}
+		}
+	}
+
+	return statuses, nil
+}
+
+func (r *ReconcileAstarte) getDeploymentStatus(ctx context.Context, cr *v1alpha2.Astarte, component v1alpha2.AstarteComponent) (v1alpha2.AstarteComponentStatus, error) {
+	status := v1alpha2.AstarteComponentStatus{Name: component.String(), Deployed: misc.IsAstarteComponentDeployed(cr, component)}
+
+	deployment := &appsv1.Deployment{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: cr.Name + "-" + component.DashedString(), Namespace: cr.Namespace}, deployment); err != nil {
+		if errors.IsNotFound(err) {
+			return status, nil
+		}
+		return status, err
+	}
+
+	status.DesiredReplicas = pointy.Int32Value(deployment.Spec.Replicas, 1)
+	status.ReadyReplicas = deployment.Status.ReadyReplicas
+	status.Image, status.Version = getImageAndVersion(deployment.Spec.Template.Spec)
+	status.Ready = status.ReadyReplicas > 0 || status.DesiredReplicas == 0
+	return status, nil
+}
+
+func (r *ReconcileAstarte) getStatefulSetStatus(ctx context.Context, cr *v1alpha2.Astarte, name string, deployed bool) (v1alpha2.AstarteComponentStatus, error) {
+	status := v1alpha2.AstarteComponentStatus{Name: name, Deployed: deployed}
+	if !deployed {
+		// The Dependency is external to the cluster, or not needed at all: there's nothing to observe.
+		return status, nil
+	}
+
+	statefulSet := &appsv1.StatefulSet{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: cr.Name + "-" + name, Namespace: cr.Namespace}, statefulSet); err != nil {
+		if errors.IsNotFound(err) {
+			return status, nil
+		}
+		return status, err
+	}
+
+	status.DesiredReplicas = pointy.Int32Value(statefulSet.Spec.Replicas, 1)
+	status.ReadyReplicas = statefulSet.Status.ReadyReplicas
+	status.Image, status.Version = getImageAndVersion(statefulSet.Spec.Template.Spec)
+	status.Ready = status.ReadyReplicas > 0 || status.DesiredReplicas == 0
+	return status, nil
+}
+
+func isDependencyDeployed(cr *v1alpha2.Astarte, dependency string) bool {
+	switch dependency {
+	case "rabbitmq":
+		return pointy.BoolValue(cr.Spec.RabbitMQ.Deploy, true)
+	case "cassandra":
+		return pointy.BoolValue(cr.Spec.Cassandra.Deploy, true)
+	case "cfssl":
+		return pointy.BoolValue(cr.Spec.CFSSL.Deploy, true)
+	}
+	return false
+}
+
+// getImageAndVersion returns the image of the main container of a Pod, and its tag
+func getImageAndVersion(podSpec v1.PodSpec) (string, string) {
+	if len(podSpec.Containers) == 0 {
+		return "", ""
+	}
+	image := podSpec.Containers[0].Image
+	// Tags come after the last colon, as long as it's not part of a registry host:port
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image, image[i+1:]
+	}
+	return image, ""
+}
+
+// countNonReadyComponents returns how many deployed Components and Dependencies are not ready
+func countNonReadyComponents(statuses []v1alpha2.AstarteComponentStatus) int {
+	nonReady := 0
+	for _, status := range statuses {
+		if status.Deployed && !status.Ready {
+			nonReady++
+		}
+	}
+	return nonReady
+}
+
+// computeHealth sums up the readiness of Components and Dependencies into the Health of the Astarte Resource
+func computeHealth(statuses []v1alpha2.AstarteComponentStatus) string {
+	switch countNonReadyComponents(statuses) {
+	case 0:
+		return "green"
+	case 1:
+		return "yellow"
+	default:
+		return "red"
+	}
+}
@@ -0,0 +1,91 @@
+package astarte
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	recon "github.com/astarte-platform/astarte-kubernetes-operator/pkg/controller/astarte/reconcile"
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+)
+
+// setReconcileFailed marks the Astarte Resource as Degraded because of reconcileErr, and updates its status. Errors
+// which need a manual intervention also move it to the Failed phase. Failing to update the status is logged, as
+// reconcileErr is what should be reported to the caller.
+func (r *ReconcileAstarte) setReconcileFailed(ctx context.Context, cr *v1alpha2.Astarte, reconcileErr *recon.ReconcileError, reqLogger logr.Logger) {
+	cr.SetCondition(v1alpha2.AstarteConditionDegraded, v1.ConditionTrue, reconcileErr.Reason, reconcileErr.Error())
+	cr.SetCondition(v1alpha2.AstarteConditionProgressing, v1.ConditionFalse, reconcileErr.Reason,
+		"Reconciliation can't progress until the error is resolved")
+	if reconcileErr.Class.IsFailure() {
+		cr.Status.ReconciliationPhase = v1alpha2.ReconciliationPhaseFailed
+	}
+	r.recorder.Eventf(cr, v1.EventTypeWarning, misc.EventReasonReconcileFailed, "%s: %v", reconcileErr.Reason, reconcileErr)
+
+	if err := r.client.Status().Update(ctx, cr); err != nil {
+		reqLogger.Error(err, "Failed to update Astarte status.")
+	}
+}
+
+// setReconcilePaused marks the Astarte Resource as Paused. Nothing is Progressing, as the Operator won't change the
+// cluster until the Resource is resumed. It does not update the status.
+func (r *ReconcileAstarte) setReconcilePaused(cr *v1alpha2.Astarte) {
+	cr.SetCondition(v1alpha2.AstarteConditionPaused, v1.ConditionTrue, v1alpha2.ReasonPaused,
+		"Reconciliation is paused, the Operator is only reporting the status of the cluster")
+	cr.SetCondition(v1alpha2.AstarteConditionProgressing, v1.ConditionFalse, v1alpha2.ReasonPaused, "")
+}
+
+// setReconcileNotPaused marks the Astarte Resource as not Paused. It does not update the status.
+func (r *ReconcileAstarte) setReconcileNotPaused(cr *v1alpha2.Astarte) {
+	cr.SetCondition(v1alpha2.AstarteConditionPaused, v1.ConditionFalse, v1alpha2.ReasonNotPaused, "")
+}
+
+// setReconcileSucceeded computes all Conditions of an Astarte Resource which went through a successful reconciliation,
+// based on its Components status. It does not update the status, as this is done together with the other status fields.
+func (r *ReconcileAstarte) setReconcileSucceeded(cr *v1alpha2.Astarte) {
+	cr.SetCondition(v1alpha2.AstarteConditionDegraded, v1.ConditionFalse, v1alpha2.ReasonReconcileSucceeded, "")
+
+	nonReadyDependencies, nonReadyComponents := []string{}, []string{}
+	for _, status := range cr.Status.Components {
+		if !status.Deployed || status.Ready {
+			continue
+		}
+		if isDependency(status.Name) {
+			nonReadyDependencies = append(nonReadyDependencies, status.Name)
+		} else {
+			nonReadyComponents = append(nonReadyComponents, status.Name)
+		}
+	}
+
+	if len(nonReadyDependencies) == 0 {
+		cr.SetCondition(v1alpha2.AstarteConditionDependenciesReady, v1.ConditionTrue, v1alpha2.ReasonDependenciesReady, "")
+	} else {
+		cr.SetCondition(v1alpha2.AstarteConditionDependenciesReady, v1.ConditionFalse, v1alpha2.ReasonDependenciesNotReady,
+			fmt.Sprintf("Waiting for %s to become ready", strings.Join(nonReadyDependencies, ", ")))
+	}
+
+	if len(nonReadyComponents) == 0 && len(nonReadyDependencies) == 0 {
+		cr.SetCondition(v1alpha2.AstarteConditionReady, v1.ConditionTrue, v1alpha2.ReasonComponentsReady, "")
+		cr.SetCondition(v1alpha2.AstarteConditionProgressing, v1.ConditionFalse, v1alpha2.ReasonReconcileSucceeded, "")
+	} else {
+		message := fmt.Sprintf("Not ready: %s", strings.Join(append(nonReadyDependencies, nonReadyComponents...), ", "))
+		cr.SetCondition(v1alpha2.AstarteConditionReady, v1.ConditionFalse, v1alpha2.ReasonComponentsNotReady, message)
+		cr.SetCondition(v1alpha2.AstarteConditionProgressing, v1.ConditionTrue, v1alpha2.ReasonComponentsNotReady, message)
+	}
+
+	// Upgrading is set by the upgrade routine only when an upgrade actually happens
+	if cr.Status.GetCondition(v1alpha2.AstarteConditionUpgrading) == nil {
+		cr.SetCondition(v1alpha2.AstarteConditionUpgrading, v1.ConditionFalse, v1alpha2.ReasonUpgradeNotRequired, "")
+	}
+}
+
+func isDependency(name string) bool {
+	for _, dependency := range astarteDependencies {
+		if name == dependency {
+			return true
+		}
+	}
+	return false
+}
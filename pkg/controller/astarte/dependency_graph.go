@@ -0,0 +1,233 @@
+package astarte
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	recon "github.com/astarte-platform/astarte-kubernetes-operator/pkg/controller/astarte/reconcile"
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/metrics"
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileStep is a node of the graph driving the reconciliation of an Astarte Resource. A step runs only once
+// all of the steps it depends on succeeded and, for those which can tell, are ready.
+type reconcileStep struct {
+	name      string
+	dependsOn []string
+	ensure    func(ctx context.Context, cr *apiv1alpha2.Astarte, c client.Client, scheme *runtime.Scheme) error
+	// isReady reports whether what the step reconciled can be used by the steps depending on it. Steps
+	// without it are considered ready as soon as they succeed.
+	isReady func(ctx context.Context, cr *apiv1alpha2.Astarte) (bool, error)
+}
+
+// reconcileStepsResult collects the outcome of a run of the dependency graph
+type reconcileStepsResult struct {
+	// Steps which were held back, with the steps they are waiting for
+	waiting map[string][]string
+	// Aggregate of the errors of all failed steps
+	err error
+}
+
+// getReconcileSteps returns the dependency graph of an Astarte Resource. Dependencies come first, then Housekeeping,
+// which creates and migrates the Database, and all other services after it.
+func (r *ReconcileAstarte) getReconcileSteps(cr *apiv1alpha2.Astarte) []reconcileStep {
+	components := cr.Spec.Components
+	genericBackend := func(backend apiv1alpha2.AstarteGenericClusteredResource, component apiv1alpha2.AstarteComponent) func(context.Context, *apiv1alpha2.Astarte, client.Client, *runtime.Scheme) error {
+		return func(ctx context.Context, cr *apiv1alpha2.Astarte, c client.Client, scheme *runtime.Scheme) error {
+			return recon.EnsureAstarteGenericBackend(ctx, cr, backend, component, c, scheme)
+		}
+	}
+	genericAPI := func(api apiv1alpha2.AstarteGenericAPISpec, component apiv1alpha2.AstarteComponent) func(context.Context, *apiv1alpha2.Astarte, client.Client, *runtime.Scheme) error {
+		return func(ctx context.Context, cr *apiv1alpha2.Astarte, c client.Client, scheme *runtime.Scheme) error {
+			return recon.EnsureAstarteGenericAPI(ctx, cr, api, component, c, scheme)
+		}
+	}
+
+	return []reconcileStep{
+		{name: "housekeeping-key", ensure: recon.EnsureHousekeepingKey},
+		{name: "erlang-configuration", ensure: recon.EnsureGenericErlangConfiguration},
+		{name: "rabbitmq", ensure: recon.EnsureRabbitMQ, isReady: r.isDependencyReady("rabbitmq")},
+		{name: "cassandra", ensure: recon.EnsureCassandra, isReady: r.isDependencyReady("cassandra")},
+		{name: "cfssl", ensure: recon.EnsureCFSSL},
+		{name: "cfssl-ca-secret", dependsOn: []string{"cfssl"}, ensure: recon.EnsureCFSSLCASecret, isReady: r.isCFSSLCASecretReady},
+		{
+			name:      string(apiv1alpha2.Housekeeping),
+			dependsOn: []string{"housekeeping-key", "erlang-configuration", "rabbitmq", "cassandra"},
+			ensure:    genericBackend(components.Housekeeping.Backend, apiv1alpha2.Housekeeping),
+		},
+		{
+			name:      string(apiv1alpha2.HousekeepingAPI),
+			dependsOn: []string{string(apiv1alpha2.Housekeeping)},
+			ensure:    genericAPI(components.Housekeeping.API, apiv1alpha2.HousekeepingAPI),
+		},
+		{
+			name:      string(apiv1alpha2.RealmManagement),
+			dependsOn: []string{string(apiv1alpha2.Housekeeping)},
+			ensure:    genericBackend(components.RealmManagement.Backend, apiv1alpha2.RealmManagement),
+		},
+		{
+			name:      string(apiv1alpha2.RealmManagementAPI),
+			dependsOn: []string{string(apiv1alpha2.RealmManagement)},
+			ensure:    genericAPI(components.RealmManagement.API, apiv1alpha2.RealmManagementAPI),
+		},
+		{
+			name:      string(apiv1alpha2.Pairing),
+			dependsOn: []string{string(apiv1alpha2.Housekeeping), "cfssl-ca-secret"},
+			ensure:    genericBackend(components.Pairing.Backend, apiv1alpha2.Pairing),
+		},
+		{
+			name:      string(apiv1alpha2.PairingAPI),
+			dependsOn: []string{string(apiv1alpha2.Pairing)},
+			ensure:    genericAPI(components.Pairing.API, apiv1alpha2.PairingAPI),
+		},
+		{
+			name:      string(apiv1alpha2.TriggerEngine),
+			dependsOn: []string{string(apiv1alpha2.Housekeeping)},
+			ensure:    genericBackend(components.TriggerEngine, apiv1alpha2.TriggerEngine),
+		},
+		{
+			name:      string(apiv1alpha2.DataUpdaterPlant),
+			dependsOn: []string{string(apiv1alpha2.TriggerEngine)},
+			ensure:    genericBackend(components.DataUpdaterPlant.AstarteGenericClusteredResource, apiv1alpha2.DataUpdaterPlant),
+		},
+		{
+			name:      string(apiv1alpha2.AppEngineAPI),
+			dependsOn: []string{string(apiv1alpha2.Housekeeping)},
+			ensure:    genericAPI(components.AppengineAPI.AstarteGenericAPISpec, apiv1alpha2.AppEngineAPI),
+		},
+		{
+			name:      verneMQComponentName,
+			dependsOn: []string{"cfssl-ca-secret", string(apiv1alpha2.DataUpdaterPlant)},
+			ensure:    recon.EnsureVerneMQ,
+		},
+		{
+			// The Dashboard is a static frontend, and can be deployed on its own
+			name: string(apiv1alpha2.Dashboard),
+			ensure: func(ctx context.Context, cr *apiv1alpha2.Astarte, c client.Client, scheme *runtime.Scheme) error {
+				return recon.EnsureAstarteDashboard(ctx, cr, components.Dashboard, c, scheme)
+			},
+		},
+	}
+}
+
+// runReconcileSteps runs all steps of the graph, each one as soon as its dependencies succeeded and are ready, so
+// that independent branches are reconciled concurrently. Steps whose dependencies failed are skipped, and those whose
+// dependencies are not ready yet wait for them. All errors are collected and returned in a single aggregate, one per
+// failed step, in the order steps are declared. Steps write to the cluster through c.
+func (r *ReconcileAstarte) runReconcileSteps(ctx context.Context, cr *apiv1alpha2.Astarte, steps []reconcileStep, c client.Client, reqLogger logr.Logger) reconcileStepsResult {
+	done := map[string]chan struct{}{}
+	for _, step := range steps {
+		done[step.name] = make(chan struct{})
+	}
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	// Steps which can't be relied upon by the ones depending on them
+	failed, notReady := map[string]bool{}, map[string]bool{}
+	waiting := map[string][]string{}
+	errs := make([]error, len(steps))
+
+	for i, step := range steps {
+		wg.Add(1)
+		go func(i int, step reconcileStep) {
+			defer wg.Done()
+			defer close(done[step.name])
+
+			failedDependencies, waitingFor := []string{}, []string{}
+			for _, dependency := range step.dependsOn {
+				<-done[dependency]
+				mutex.Lock()
+				if failed[dependency] {
+					failedDependencies = append(failedDependencies, dependency)
+				} else if notReady[dependency] {
+					waitingFor = append(waitingFor, dependency)
+				}
+				mutex.Unlock()
+			}
+
+			var ready bool
+			var err error
+			if len(failedDependencies) == 0 && len(waitingFor) == 0 {
+				ready, err = r.runReconcileStep(ctx, cr, step, c)
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			switch {
+			case len(failedDependencies) > 0:
+				// Skipped steps are reported through the failure which caused them
+				reqLogger.Info("Skipping Component, as some of its dependencies failed to reconcile",
+					"Astarte.Component", step.name, "Dependencies", failedDependencies)
+				failed[step.name] = true
+			case len(waitingFor) > 0:
+				reqLogger.Info("Component is waiting for its dependencies to become ready",
+					"Astarte.Component", step.name, "Dependencies", waitingFor)
+				notReady[step.name] = true
+				waiting[step.name] = waitingFor
+			case err != nil:
+				reqLogger.Error(err, "Failed to reconcile Component", "Astarte.Component", step.name)
+				failed[step.name] = true
+				// The error is wrapped, so that it can still be classified
+				errs[i] = fmt.Errorf("%s: %w", step.name, err)
+			case !ready:
+				notReady[step.name] = true
+			}
+		}(i, step)
+	}
+
+	wg.Wait()
+	return reconcileStepsResult{waiting: waiting, err: utilerrors.NewAggregate(errs)}
+}
+
+func (r *ReconcileAstarte) runReconcileStep(ctx context.Context, cr *apiv1alpha2.Astarte, step reconcileStep, c client.Client) (bool, error) {
+	start := time.Now()
+	err := step.ensure(ctx, cr, c, r.scheme)
+	// Planned steps don't change anything, and would skew the metrics
+	if !cr.IsPlanning() {
+		errorClass := ""
+		if err != nil {
+			errorClass = string(recon.ClassifyError(err).Class)
+		}
+		metrics.ObserveComponentReconcile(cr, step.name, time.Since(start), errorClass)
+	}
+	if err != nil {
+		return false, err
+	}
+	if step.isReady == nil {
+		return true, nil
+	}
+	return step.isReady(ctx, cr)
+}
+
+// isDependencyReady returns a readiness check for a Dependency deployed as a StatefulSet. Dependencies which are not
+// deployed by the Operator are assumed to be ready.
+func (r *ReconcileAstarte) isDependencyReady(dependency string) func(ctx context.Context, cr *apiv1alpha2.Astarte) (bool, error) {
+	return func(ctx context.Context, cr *apiv1alpha2.Astarte) (bool, error) {
+		status, err := r.getStatefulSetStatus(ctx, cr, dependency, isDependencyDeployed(cr, dependency))
+		if err != nil {
+			return false, err
+		}
+		return !status.Deployed || status.Ready, nil
+	}
+}
+
+// isCFSSLCASecretReady reports whether the CFSSL CA Job already stored the CA in its Secret
+func (r *ReconcileAstarte) isCFSSLCASecretReady(ctx context.Context, cr *apiv1alpha2.Astarte) (bool, error) {
+	theSecret := &v1.Secret{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: cr.Name + "-cfssl-ca", Namespace: cr.Namespace}, theSecret); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
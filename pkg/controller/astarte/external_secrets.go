@@ -0,0 +1,42 @@
+package astarte
+
+import (
+	"context"
+
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// getExternalSecretNames returns the names of the Secrets provided by the user and consumed by an Astarte Resource
+func getExternalSecretNames(cr *apiv1alpha2.Astarte) []string {
+	names := []string{}
+	if cr.Spec.RabbitMQ.Connection != nil && cr.Spec.RabbitMQ.Connection.Secret != nil {
+		names = append(names, cr.Spec.RabbitMQ.Connection.Secret.Name)
+	}
+	return names
+}
+
+// getExternalSecretsMapper returns a Mapper requeueing all Astarte Resources which consume a Secret provided by the
+// user, so that Pods are rolled out when it changes. Astarte Resources are listed within ctx, through c.
+func getExternalSecretsMapper(ctx context.Context, c client.Client) handler.Mapper {
+	return handler.ToRequestsFunc(func(obj handler.MapObject) []reconcile.Request {
+		astartes := &apiv1alpha2.AstarteList{}
+		if err := c.List(ctx, astartes, client.InNamespace(obj.Meta.GetNamespace())); err != nil {
+			log.Error(err, "Could not list Astarte Resources consuming Secret", "Secret", obj.Meta.GetName())
+			return nil
+		}
+
+		requests := []reconcile.Request{}
+		for i := range astartes.Items {
+			if contains(getExternalSecretNames(&astartes.Items[i]), obj.Meta.GetName()) {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: astartes.Items[i].Name, Namespace: astartes.Items[i].Namespace},
+				})
+			}
+		}
+		return requests
+	})
+}
@@ -4,7 +4,8 @@ import (
 	"context"
 	"strings"
 
-	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha1"
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
@@ -13,15 +14,15 @@ import (
 
 const astarteFinalizer = "finalizer.astarte.astarte-platform.org"
 
-func (r *ReconcileAstarte) finalizeAstarte(cr *v1alpha1.Astarte) error {
+func (r *ReconcileAstarte) finalizeAstarte(ctx context.Context, cr *v1alpha2.Astarte) error {
 	reqLogger := log.WithValues("Request.Namespace", cr.Namespace, "Request.Name", cr.Name)
 	reqLogger.Info("Finalizing Astarte")
 
 	// First of all - do we have the CA Secret still around?
 	theSecret := &v1.Secret{}
-	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: cr.Name + "-cfssl-ca", Namespace: cr.Namespace}, theSecret); err == nil {
+	if err := r.client.Get(ctx, types.NamespacedName{Name: cr.Name + "-cfssl-ca", Namespace: cr.Namespace}, theSecret); err == nil {
 		// The secret is there. Delete it.
-		if err := r.client.Delete(context.TODO(), theSecret); err != nil {
+		if err := r.client.Delete(ctx, theSecret); err != nil {
 			reqLogger.Error(err, "Error while finalizing Astarte. CFSSL CA Secret will need to be manually removed.")
 		}
 	}
@@ -37,14 +38,18 @@ func (r *ReconcileAstarte) finalizeAstarte(cr *v1alpha1.Astarte) error {
 	}
 
 	pvcs := &v1.PersistentVolumeClaimList{}
-	if err := r.client.List(context.TODO(), pvcs, client.InNamespace(cr.Namespace)); err == nil {
+	if err := r.client.List(ctx, pvcs, client.InNamespace(cr.Namespace)); err == nil {
 		// Iterate and delete
 		for _, pvc := range pvcs.Items {
 			for _, prefix := range erasePVCPrefixes {
 				if strings.HasPrefix(pvc.GetName(), prefix) {
 					// Delete.
-					if err := r.client.Delete(context.TODO(), &pvc); err != nil {
+					if err := r.client.Delete(ctx, &pvc); err != nil {
 						reqLogger.Error(err, "Error while finalizing Astarte. A PersistentVolumeClaim will need to be manually removed.", "PVC", pvc)
+						misc.RecordWarningEvent(ctx, cr, misc.EventReasonPersistentVolumeClaimDeleted,
+							"Could not delete PersistentVolumeClaim %s, it will need to be manually removed: %v", pvc.GetName(), err)
+					} else {
+						misc.RecordEvent(ctx, cr, misc.EventReasonPersistentVolumeClaimDeleted, "Deleted PersistentVolumeClaim %s", pvc.GetName())
 					}
 					break
 				}
@@ -57,16 +62,17 @@ func (r *ReconcileAstarte) finalizeAstarte(cr *v1alpha1.Astarte) error {
 
 	// That's it. So long, and thanks for all the fish.
 	reqLogger.Info("Successfully finalized astarte")
+	misc.RecordEvent(ctx, cr, misc.EventReasonFinalized, "Astarte was finalized")
 	return nil
 }
 
-func (r *ReconcileAstarte) addFinalizer(cr *v1alpha1.Astarte) error {
+func (r *ReconcileAstarte) addFinalizer(ctx context.Context, cr *v1alpha2.Astarte) error {
 	reqLogger := log.WithValues("Request.Namespace", cr.Namespace, "Request.Name", cr.Name)
 	reqLogger.Info("Adding Astarte Finalizer")
 	cr.SetFinalizers(append(cr.GetFinalizers(), astarteFinalizer))
 
 	// Update CR
-	err := r.client.Update(context.TODO(), cr)
+	err := r.client.Update(ctx, cr)
 	if err != nil {
 		reqLogger.Error(err, "Failed to update Astarte with finalizer")
 		return err
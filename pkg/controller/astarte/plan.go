@@ -0,0 +1,201 @@
+package astarte
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// planningClient reads through to the cluster, and turns every write into a dry run whose outcome is recorded as a
+// planned change. Writes never fail: errors returned by the API Server are part of the plan.
+type planningClient struct {
+	client.Client
+	scheme *runtime.Scheme
+
+	mutex   sync.Mutex
+	changes []apiv1alpha2.AstartePlannedChange
+}
+
+func newPlanningClient(c client.Client, scheme *runtime.Scheme) *planningClient {
+	return &planningClient{Client: c, scheme: scheme}
+}
+
+// Create implements client.Writer
+func (p *planningClient) Create(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+	err := p.Client.Create(ctx, obj, append(opts, client.DryRunAll)...)
+	p.record(apiv1alpha2.PlannedActionCreate, obj, false, err)
+	return nil
+}
+
+// Update implements client.Writer. Updates which wouldn't change anything once defaulted by the API Server are
+// not part of the plan.
+func (p *planningClient) Update(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+	live, err := p.getLiveObject(ctx, obj)
+	if err != nil {
+		return err
+	}
+	err = p.Client.Update(ctx, obj, append(opts, client.DryRunAll)...)
+	p.recordUpdate(live, obj, err)
+	return nil
+}
+
+// Patch implements client.Writer, just like Update. Server-side apply creates missing objects, and so does its plan.
+func (p *planningClient) Patch(ctx context.Context, obj runtime.Object, patch client.Patch, opts ...client.PatchOption) error {
+	live, err := p.getLiveObject(ctx, obj)
+	if errors.IsNotFound(err) && patch.Type() == types.ApplyPatchType {
+		err = p.Client.Patch(ctx, obj, patch, append(opts, client.DryRunAll)...)
+		p.record(apiv1alpha2.PlannedActionCreate, obj, false, err)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	err = p.Client.Patch(ctx, obj, patch, append(opts, client.DryRunAll)...)
+	p.recordUpdate(live, obj, err)
+	return nil
+}
+
+// Delete implements client.Writer
+func (p *planningClient) Delete(ctx context.Context, obj runtime.Object, opts ...client.DeleteOption) error {
+	err := p.Client.Delete(ctx, obj, append(opts, client.DryRunAll)...)
+	p.record(apiv1alpha2.PlannedActionDelete, obj, false, err)
+	return nil
+}
+
+// DeleteAllOf implements client.Writer. The API Server can't dry run it, so it can't be planned either.
+func (p *planningClient) DeleteAllOf(ctx context.Context, obj runtime.Object, opts ...client.DeleteAllOfOption) error {
+	return fmt.Errorf("deleting collections can't be planned")
+}
+
+// Status implements client.StatusClient. Status updates are not part of the plan, and are dropped.
+func (p *planningClient) Status() client.StatusWriter {
+	return planningStatusWriter{}
+}
+
+// getChanges returns all planned changes, sorted by Kind and Name
+func (p *planningClient) getChanges() []apiv1alpha2.AstartePlannedChange {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	changes := append([]apiv1alpha2.AstartePlannedChange{}, p.changes...)
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Kind != changes[j].Kind {
+			return changes[i].Kind < changes[j].Kind
+		}
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+func (p *planningClient) getLiveObject(ctx context.Context, obj runtime.Object) (runtime.Object, error) {
+	key, err := client.ObjectKeyFromObject(obj)
+	if err != nil {
+		return nil, err
+	}
+	live := obj.DeepCopyObject()
+	if err := p.Client.Get(ctx, key, live); err != nil {
+		return nil, err
+	}
+	return live, nil
+}
+
+func (p *planningClient) recordUpdate(live, updated runtime.Object, err error) {
+	if err != nil {
+		p.record(apiv1alpha2.PlannedActionUpdate, updated, false, err)
+		return
+	}
+
+	liveContent, liveErr := misc.GetComparableContent(live)
+	updatedContent, updatedErr := misc.GetComparableContent(updated)
+	if liveErr == nil && updatedErr == nil && reflect.DeepEqual(liveContent, updatedContent) {
+		return
+	}
+	p.record(apiv1alpha2.PlannedActionUpdate, updated, rollsPods(live, updated), nil)
+}
+
+func (p *planningClient) record(action apiv1alpha2.AstartePlannedAction, obj runtime.Object, rollsPods bool, err error) {
+	change := apiv1alpha2.AstartePlannedChange{Action: action, RollsPods: rollsPods}
+	if gvk, gvkErr := apiutil.GVKForObject(obj, p.scheme); gvkErr == nil {
+		change.Kind = gvk.Kind
+	}
+	if accessor, accessorErr := meta.Accessor(obj); accessorErr == nil {
+		change.Name = accessor.GetName()
+	}
+	if err != nil {
+		change.Error = err.Error()
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.changes = append(p.changes, change)
+}
+
+// rollsPods returns whether updating a Deployment or StatefulSet from live to updated would roll its Pods
+func rollsPods(live, updated runtime.Object) bool {
+	switch l := live.(type) {
+	case *appsv1.Deployment:
+		u, ok := updated.(*appsv1.Deployment)
+		return ok && !equality.Semantic.DeepEqual(l.Spec.Template, u.Spec.Template)
+	case *appsv1.StatefulSet:
+		u, ok := updated.(*appsv1.StatefulSet)
+		return ok && !equality.Semantic.DeepEqual(l.Spec.Template, u.Spec.Template)
+	}
+	return false
+}
+
+type planningStatusWriter struct{}
+
+func (planningStatusWriter) Update(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+	return nil
+}
+
+func (planningStatusWriter) Patch(ctx context.Context, obj runtime.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return nil
+}
+
+// planAstarte computes the changes a reconciliation would apply to the cluster, and reports them in the status of the
+// Astarte Resource rather than applying them
+func (r *ReconcileAstarte) planAstarte(ctx context.Context, instance *apiv1alpha2.Astarte, reqLogger logr.Logger) (reconcile.Result, error) {
+	reqLogger.Info("Planning changes to Astarte, without applying them")
+
+	plan := &apiv1alpha2.AstartePlan{ObservedGeneration: instance.Generation, GeneratedAt: metav1.Now()}
+	if instance.Status.AstarteVersion != "" && instance.Status.AstarteVersion != instance.Spec.Version {
+		plan.Notes = append(plan.Notes, fmt.Sprintf("Astarte would be upgraded from %s to %s before applying any change",
+			instance.Status.AstarteVersion, instance.Spec.Version))
+	}
+
+	// Nothing gets created, so there's no point in waiting for anything to become ready
+	steps := r.getReconcileSteps(instance)
+	for i := range steps {
+		steps[i].isReady = nil
+	}
+	planner := newPlanningClient(r.client, r.scheme)
+	if stepsResult := r.runReconcileSteps(ctx, instance, steps, planner, reqLogger); stepsResult.err != nil {
+		plan.Notes = append(plan.Notes, fmt.Sprintf("Reconciliation would fail: %v", stepsResult.err))
+	}
+	plan.Changes = planner.getChanges()
+
+	instance.Status.Plan = plan
+	if err := r.client.Status().Update(ctx, instance); err != nil {
+		reqLogger.Error(err, "Failed to update Astarte status.")
+		return reconcile.Result{}, err
+	}
+
+	reqLogger.Info("Astarte changes planned", "Changes", len(plan.Changes))
+	return reconcile.Result{}, nil
+}
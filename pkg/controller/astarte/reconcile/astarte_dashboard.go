@@ -4,7 +4,7 @@ import (
 	"context"
 	"encoding/json"
 
-	apiv1alpha1 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha1"
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
 	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
 	"github.com/openlyinc/pointy"
 	appsv1 "k8s.io/api/apps/v1"
@@ -18,7 +18,7 @@ import (
 )
 
 // EnsureAstarteDashboard reconciles Astarte Dashboard
-func EnsureAstarteDashboard(cr *apiv1alpha1.Astarte, dashboard apiv1alpha1.AstarteDashboardSpec, c client.Client, scheme *runtime.Scheme) error {
+func EnsureAstarteDashboard(ctx context.Context, cr *apiv1alpha2.Astarte, dashboard apiv1alpha2.AstarteDashboardSpec, c client.Client, scheme *runtime.Scheme) error {
 	reqLogger := log.WithValues("Request.Namespace", cr.Namespace, "Request.Name", cr.Name, "Astarte.Component", "dashboard")
 	deploymentName := cr.Name + "-dashboard"
 	serviceName := cr.Name + "-dashboard"
@@ -29,33 +29,47 @@ func EnsureAstarteDashboard(cr *apiv1alpha1.Astarte, dashboard apiv1alpha1.Astar
 	}
 	matchLabels := map[string]string{"app": deploymentName}
 
+	// The Disruption Budget follows the replicas of the Component, and goes away together with it
+	if err := ensurePodDisruptionBudget(ctx, deploymentName, matchLabels,
+		getClusteredResourceForDisruptionBudget(dashboard.AstarteGenericClusteredResource, dashboard.Autoscaling), cr, c, scheme); err != nil {
+		return err
+	}
+
+	// So does the Autoscaler, which owns the Component's replicas when enabled
+	if err := ensureHorizontalPodAutoscaler(ctx, deploymentName, labels, dashboard.Autoscaling, pointy.BoolValue(dashboard.Deploy, true), cr, c, scheme); err != nil {
+		return err
+	}
+
 	// Ok. Shall we deploy?
-	if !pointy.BoolValue(dashboard.GenericClusteredResource.Deploy, true) {
+	if !pointy.BoolValue(dashboard.Deploy, true) {
 		reqLogger.V(1).Info("Skipping Astarte Dashboard Deployment")
-		// Before returning - check if we shall clean up the Deployment.
-		// It is the only thing actually requiring resources, the rest will be cleaned up eventually when the
-		// Astarte resource is deleted.
+		// Before returning - clean up everything in the Component's inventory. The Deployment is looked up by
+		// name too, as it might predate the inventory.
 		theDeployment := &appsv1.Deployment{}
-		err := c.Get(context.TODO(), types.NamespacedName{Name: deploymentName, Namespace: cr.Namespace}, theDeployment)
+		err := c.Get(ctx, types.NamespacedName{Name: deploymentName, Namespace: cr.Namespace}, theDeployment)
 		if err == nil {
 			reqLogger.Info("Deleting previously existing Component Deployment, which is no longer needed")
-			if err = c.Delete(context.TODO(), theDeployment); err != nil {
+			if err := misc.DeleteOwnedResource(ctx, cr, theDeployment, c); err != nil {
 				return err
 			}
 		}
 
+		if err := pruneComponentInventory(ctx, "dashboard", cr, c); err != nil {
+			return err
+		}
+
 		// That would be all for today.
 		return nil
 	}
 
 	// Good. Reconcile the ConfigMap.
-	if _, err := reconcileConfigMap(deploymentName+"-config", getAstarteDashboardConfigMapData(cr, dashboard), cr, c, scheme); err != nil {
+	if _, err := reconcileConfigMap(ctx, deploymentName+"-config", "dashboard", getAstarteDashboardConfigMapData(cr, dashboard), cr, c, scheme); err != nil {
 		return err
 	}
 
 	// Good. Now, reconcile the service first of all.
 	service := &v1.Service{ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: cr.Namespace}}
-	if result, err := controllerutil.CreateOrUpdate(context.TODO(), c, service, func() error {
+	if result, err := applyComponentObject(ctx, "dashboard", cr, c, scheme, service, func() error {
 		if err := controllerutil.SetControllerReference(cr, service, scheme); err != nil {
 			return err
 		}
@@ -74,7 +88,7 @@ func EnsureAstarteDashboard(cr *apiv1alpha1.Astarte, dashboard apiv1alpha1.Astar
 		service.Spec.Selector = matchLabels
 		return nil
 	}); err == nil {
-		logCreateOrUpdateOperationResult(result, cr, service)
+		logCreateOrUpdateOperationResult(ctx, result, cr, service)
 	} else {
 		return err
 	}
@@ -85,16 +99,19 @@ func EnsureAstarteDashboard(cr *apiv1alpha1.Astarte, dashboard apiv1alpha1.Astar
 		},
 		Strategy: cr.Spec.DeploymentStrategy,
 		Template: v1.PodTemplateSpec{
-			ObjectMeta: metav1.ObjectMeta{
-				Labels: labels,
-			},
-			Spec: getAstarteDashboardPodSpec(deploymentName, cr, dashboard),
+			ObjectMeta: getPodTemplateObjectMeta(labels, dashboard.AstartePodSchedulingSpec, dashboard.AstartePodSecuritySpec),
+			Spec:       getAstarteDashboardPodSpec(deploymentName, cr, dashboard),
 		},
 	}
 
+	// Roll the Pods whenever the configuration or credentials they consume change
+	if err := setConfigurationChecksums(ctx, &deploymentSpec.Template, cr, c); err != nil {
+		return err
+	}
+
 	// Build the Deployment
 	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: deploymentName, Namespace: cr.Namespace}}
-	result, err := controllerutil.CreateOrUpdate(context.TODO(), c, deployment, func() error {
+	result, err := applyComponentObject(ctx, "dashboard", cr, c, scheme, deployment, func() error {
 		if err := controllerutil.SetControllerReference(cr, deployment, scheme); err != nil {
 			return err
 		}
@@ -102,7 +119,7 @@ func EnsureAstarteDashboard(cr *apiv1alpha1.Astarte, dashboard apiv1alpha1.Astar
 		// Assign the Spec.
 		deployment.ObjectMeta.Labels = labels
 		deployment.Spec = deploymentSpec
-		deployment.Spec.Replicas = dashboard.GenericClusteredResource.Replicas
+		deployment.Spec.Replicas = getReplicasForDeployment(dashboard.AstarteGenericClusteredResource, dashboard.Autoscaling)
 
 		return nil
 	})
@@ -110,12 +127,12 @@ func EnsureAstarteDashboard(cr *apiv1alpha1.Astarte, dashboard apiv1alpha1.Astar
 		return err
 	}
 
-	logCreateOrUpdateOperationResult(result, cr, deployment)
+	logCreateOrUpdateOperationResult(ctx, result, cr, deployment)
 	return nil
 }
 
-func getAstarteDashboardPodSpec(deploymentName string, cr *apiv1alpha1.Astarte, dashboard apiv1alpha1.AstarteDashboardSpec) v1.PodSpec {
-	component := apiv1alpha1.Dashboard
+func getAstarteDashboardPodSpec(deploymentName string, cr *apiv1alpha2.Astarte, dashboard apiv1alpha2.AstarteDashboardSpec) v1.PodSpec {
+	component := apiv1alpha2.Dashboard
 	ps := v1.PodSpec{
 		TerminationGracePeriodSeconds: pointy.Int64(30),
 		ImagePullSecrets:              cr.Spec.ImagePullSecrets,
@@ -126,19 +143,23 @@ func getAstarteDashboardPodSpec(deploymentName string, cr *apiv1alpha1.Astarte,
 					v1.ContainerPort{Name: "http", ContainerPort: 80},
 				},
 				VolumeMounts:    getAstarteDashboardVolumeMounts(),
-				Image:           getAstarteImageForClusteredResource(component.DockerImageName(), dashboard.GenericClusteredResource, cr),
+				Image:           getAstarteImageForClusteredResource(component.DockerImageName(), dashboard.AstarteGenericClusteredResource, cr),
 				ImagePullPolicy: getImagePullPolicy(cr),
-				Resources:       misc.GetResourcesForAstarteComponent(cr, dashboard.GenericClusteredResource.Resources, component),
+				Resources:       misc.GetResourcesForAstarteComponent(cr, dashboard.Resources, component),
 				Env:             getAstarteDashboardEnvVars(),
 			},
 		},
 		Volumes: getAstarteDashboardVolumes(cr),
 	}
 
+	applyPodSchedulingSpec(&ps, dashboard.AstartePodSchedulingSpec)
+	applyPodExtensionsSpec(&ps, dashboard.AstartePodExtensionsSpec)
+	applyPodSecuritySpec(&ps, dashboard.AstartePodSecuritySpec)
+
 	return ps
 }
 
-func getAstarteDashboardConfigMapData(cr *apiv1alpha1.Astarte, dashboard apiv1alpha1.AstarteDashboardSpec) map[string]string {
+func getAstarteDashboardConfigMapData(cr *apiv1alpha2.Astarte, dashboard apiv1alpha2.AstarteDashboardSpec) map[string]string {
 	dashboardConfig := make(map[string]interface{})
 	if dashboard.Config.RealmManagementAPIURL != "" {
 		dashboardConfig["realm_management_api_url"] = getBaseAstarteAPIURL(cr) + "/realmmanagement/v1/"
@@ -156,7 +177,7 @@ func getAstarteDashboardConfigMapData(cr *apiv1alpha1.Astarte, dashboard apiv1al
 	if len(dashboard.Config.Auth) > 0 {
 		dashboardConfig["auth"] = dashboard.Config.Auth
 	} else {
-		dashboardConfig["auth"] = []apiv1alpha1.AstarteDashboardConfigAuthSpec{apiv1alpha1.AstarteDashboardConfigAuthSpec{Type: "token"}}
+		dashboardConfig["auth"] = []apiv1alpha2.AstarteDashboardConfigAuthSpec{apiv1alpha2.AstarteDashboardConfigAuthSpec{Type: "token"}}
 	}
 
 	configJSON, _ := json.Marshal(dashboardConfig)
@@ -166,7 +187,7 @@ func getAstarteDashboardConfigMapData(cr *apiv1alpha1.Astarte, dashboard apiv1al
 	}
 }
 
-func getAstarteDashboardVolumes(cr *apiv1alpha1.Astarte) []v1.Volume {
+func getAstarteDashboardVolumes(cr *apiv1alpha2.Astarte) []v1.Volume {
 	return []v1.Volume{
 		v1.Volume{
 			Name: "config",
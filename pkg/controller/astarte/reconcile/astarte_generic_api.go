@@ -7,7 +7,7 @@ import (
 	"strings"
 
 	semver "github.com/Masterminds/semver/v3"
-	apiv1alpha1 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha1"
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
 	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
 	"github.com/openlyinc/pointy"
 	appsv1 "k8s.io/api/apps/v1"
@@ -21,7 +21,7 @@ import (
 )
 
 // EnsureAstarteGenericAPI reconciles any component compatible with AstarteGenericAPISpec
-func EnsureAstarteGenericAPI(cr *apiv1alpha1.Astarte, api apiv1alpha1.AstarteGenericAPISpec, component apiv1alpha1.AstarteComponent, c client.Client, scheme *runtime.Scheme) error {
+func EnsureAstarteGenericAPI(ctx context.Context, cr *apiv1alpha2.Astarte, api apiv1alpha2.AstarteGenericAPISpec, component apiv1alpha2.AstarteComponent, c client.Client, scheme *runtime.Scheme) error {
 	reqLogger := log.WithValues("Request.Namespace", cr.Namespace, "Request.Name", cr.Name, "Astarte.Component", component)
 	deploymentName := cr.Name + "-" + component.DashedString()
 	serviceName := cr.Name + "-" + component.ServiceName()
@@ -32,33 +32,47 @@ func EnsureAstarteGenericAPI(cr *apiv1alpha1.Astarte, api apiv1alpha1.AstarteGen
 	}
 	matchLabels := map[string]string{"app": deploymentName}
 
+	// The Disruption Budget follows the replicas of the Component, and goes away together with it
+	if err := ensurePodDisruptionBudget(ctx, deploymentName, matchLabels,
+		getClusteredResourceForDisruptionBudget(api.AstarteGenericClusteredResource, api.Autoscaling), cr, c, scheme); err != nil {
+		return err
+	}
+
+	// So does the Autoscaler, which owns the Component's replicas when enabled
+	if err := ensureHorizontalPodAutoscaler(ctx, deploymentName, labels, api.Autoscaling, pointy.BoolValue(api.Deploy, true), cr, c, scheme); err != nil {
+		return err
+	}
+
 	// Ok. Shall we deploy?
-	if !pointy.BoolValue(api.GenericClusteredResource.Deploy, true) {
+	if !pointy.BoolValue(api.Deploy, true) {
 		reqLogger.V(1).Info("Skipping Astarte Component Deployment")
-		// Before returning - check if we shall clean up the Deployment.
-		// It is the only thing actually requiring resources, the rest will be cleaned up eventually when the
-		// Astarte resource is deleted.
+		// Before returning - clean up everything in the Component's inventory. The Deployment is looked up by
+		// name too, as it might predate the inventory.
 		theDeployment := &appsv1.Deployment{}
-		err := c.Get(context.TODO(), types.NamespacedName{Name: deploymentName, Namespace: cr.Namespace}, theDeployment)
+		err := c.Get(ctx, types.NamespacedName{Name: deploymentName, Namespace: cr.Namespace}, theDeployment)
 		if err == nil {
 			reqLogger.Info("Deleting previously existing Component Deployment, which is no longer needed")
-			if err = c.Delete(context.TODO(), theDeployment); err != nil {
+			if err := misc.DeleteOwnedResource(ctx, cr, theDeployment, c); err != nil {
 				return err
 			}
 		}
 
+		if err := pruneComponentInventory(ctx, component.DashedString(), cr, c); err != nil {
+			return err
+		}
+
 		// That would be all for today.
 		return nil
 	}
 
 	// First of all, check if we need to regenerate the cookie.
-	if err := ensureErlangCookieSecret(deploymentName+"-cookie", cr, c, scheme); err != nil {
+	if err := ensureErlangCookieSecret(ctx, deploymentName+"-cookie", component.DashedString(), cr, c, scheme); err != nil {
 		return err
 	}
 
 	// Good. Now, reconcile the service first of all.
 	service := &v1.Service{ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: cr.Namespace}}
-	if result, err := controllerutil.CreateOrUpdate(context.TODO(), c, service, func() error {
+	if result, err := applyComponentObject(ctx, component.DashedString(), cr, c, scheme, service, func() error {
 		if err := controllerutil.SetControllerReference(cr, service, scheme); err != nil {
 			return err
 		}
@@ -77,7 +91,7 @@ func EnsureAstarteGenericAPI(cr *apiv1alpha1.Astarte, api apiv1alpha1.AstarteGen
 		service.Spec.Selector = matchLabels
 		return nil
 	}); err == nil {
-		logCreateOrUpdateOperationResult(result, cr, service)
+		logCreateOrUpdateOperationResult(ctx, result, cr, service)
 	} else {
 		return err
 	}
@@ -88,16 +102,19 @@ func EnsureAstarteGenericAPI(cr *apiv1alpha1.Astarte, api apiv1alpha1.AstarteGen
 		},
 		Strategy: cr.Spec.DeploymentStrategy,
 		Template: v1.PodTemplateSpec{
-			ObjectMeta: metav1.ObjectMeta{
-				Labels: labels,
-			},
-			Spec: getAstarteGenericAPIPodSpec(deploymentName, cr, api, component),
+			ObjectMeta: getPodTemplateObjectMeta(labels, api.AstartePodSchedulingSpec, getHardenedPodSecuritySpec(api.AstartePodSecuritySpec)),
+			Spec:       getAstarteGenericAPIPodSpec(deploymentName, cr, api, component),
 		},
 	}
 
+	// Roll the Pods whenever the configuration or credentials they consume change
+	if err := setConfigurationChecksums(ctx, &deploymentSpec.Template, cr, c); err != nil {
+		return err
+	}
+
 	// Build the Deployment
 	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: deploymentName, Namespace: cr.Namespace}}
-	result, err := controllerutil.CreateOrUpdate(context.TODO(), c, deployment, func() error {
+	result, err := applyComponentObject(ctx, component.DashedString(), cr, c, scheme, deployment, func() error {
 		if err := controllerutil.SetControllerReference(cr, deployment, scheme); err != nil {
 			return err
 		}
@@ -105,7 +122,7 @@ func EnsureAstarteGenericAPI(cr *apiv1alpha1.Astarte, api apiv1alpha1.AstarteGen
 		// Assign the Spec.
 		deployment.ObjectMeta.Labels = labels
 		deployment.Spec = deploymentSpec
-		deployment.Spec.Replicas = api.GenericClusteredResource.Replicas
+		deployment.Spec.Replicas = getReplicasForDeployment(api.AstarteGenericClusteredResource, api.Autoscaling)
 
 		return nil
 	})
@@ -113,15 +130,15 @@ func EnsureAstarteGenericAPI(cr *apiv1alpha1.Astarte, api apiv1alpha1.AstarteGen
 		return err
 	}
 
-	logCreateOrUpdateOperationResult(result, cr, deployment)
+	logCreateOrUpdateOperationResult(ctx, result, cr, deployment)
 	return nil
 }
 
-func getAstarteGenericAPIPodSpec(deploymentName string, cr *apiv1alpha1.Astarte, api apiv1alpha1.AstarteGenericAPISpec, component apiv1alpha1.AstarteComponent) v1.PodSpec {
+func getAstarteGenericAPIPodSpec(deploymentName string, cr *apiv1alpha2.Astarte, api apiv1alpha2.AstarteGenericAPISpec, component apiv1alpha2.AstarteComponent) v1.PodSpec {
 	ps := v1.PodSpec{
 		TerminationGracePeriodSeconds: pointy.Int64(30),
 		ImagePullSecrets:              cr.Spec.ImagePullSecrets,
-		Affinity:                      getAffinityForClusteredResource(deploymentName, api.GenericClusteredResource),
+		Affinity:                      getAffinityForClusteredResource(deploymentName, api.AstarteGenericClusteredResource),
 		Containers: []v1.Container{
 			v1.Container{
 				Name: component.DashedString(),
@@ -129,9 +146,9 @@ func getAstarteGenericAPIPodSpec(deploymentName string, cr *apiv1alpha1.Astarte,
 					v1.ContainerPort{Name: "http", ContainerPort: 4000},
 				},
 				VolumeMounts:    getAstarteGenericAPIVolumeMounts(deploymentName, cr, api, component),
-				Image:           getAstarteImageForClusteredResource(component.DockerImageName(), api.GenericClusteredResource, cr),
+				Image:           getAstarteImageForClusteredResource(component.DockerImageName(), api.AstarteGenericClusteredResource, cr),
 				ImagePullPolicy: getImagePullPolicy(cr),
-				Resources:       misc.GetResourcesForAstarteComponent(cr, api.GenericClusteredResource.Resources, component),
+				Resources:       misc.GetResourcesForAstarteComponent(cr, api.Resources, component),
 				Env:             getAstarteGenericAPIEnvVars(deploymentName, cr, api, component),
 				ReadinessProbe:  getAstarteAPIProbe(cr, api, component),
 				LivenessProbe:   getAstarteAPIProbe(cr, api, component),
@@ -140,15 +157,19 @@ func getAstarteGenericAPIPodSpec(deploymentName string, cr *apiv1alpha1.Astarte,
 		Volumes: getAstarteGenericAPIVolumes(deploymentName, cr, api, component),
 	}
 
+	applyPodSchedulingSpec(&ps, api.AstartePodSchedulingSpec)
+	applyPodExtensionsSpec(&ps, api.AstartePodExtensionsSpec)
+	applyPodSecuritySpec(&ps, getHardenedPodSecuritySpec(api.AstartePodSecuritySpec))
+
 	return ps
 }
 
-func getAstarteGenericAPIVolumes(deploymentName string, cr *apiv1alpha1.Astarte, api apiv1alpha1.AstarteGenericAPISpec, component apiv1alpha1.AstarteComponent) []v1.Volume {
-	ret := getAstarteCommonVolumes(cr)
+func getAstarteGenericAPIVolumes(deploymentName string, cr *apiv1alpha2.Astarte, api apiv1alpha2.AstarteGenericAPISpec, component apiv1alpha2.AstarteComponent) []v1.Volume {
+	ret := getAstarteCommonVolumes(cr, api.AstartePodSecuritySpec)
 
 	// Depending on the component, we might need to add some more stuff.
 	switch component {
-	case apiv1alpha1.HousekeepingAPI:
+	case apiv1alpha2.HousekeepingAPI:
 		ret = append(ret, v1.Volume{
 			Name: "jwtpubkey",
 			VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{
@@ -160,12 +181,12 @@ func getAstarteGenericAPIVolumes(deploymentName string, cr *apiv1alpha1.Astarte,
 	return ret
 }
 
-func getAstarteGenericAPIVolumeMounts(deploymentName string, cr *apiv1alpha1.Astarte, api apiv1alpha1.AstarteGenericAPISpec, component apiv1alpha1.AstarteComponent) []v1.VolumeMount {
-	ret := getAstarteCommonVolumeMounts()
+func getAstarteGenericAPIVolumeMounts(deploymentName string, cr *apiv1alpha2.Astarte, api apiv1alpha2.AstarteGenericAPISpec, component apiv1alpha2.AstarteComponent) []v1.VolumeMount {
+	ret := getAstarteCommonVolumeMounts(api.AstartePodSecuritySpec)
 
 	// Depending on the component, we might need to add some more stuff.
 	switch component {
-	case apiv1alpha1.HousekeepingAPI:
+	case apiv1alpha2.HousekeepingAPI:
 		ret = append(ret, v1.VolumeMount{
 			Name:      "jwtpubkey",
 			MountPath: "/jwtpubkey",
@@ -176,8 +197,8 @@ func getAstarteGenericAPIVolumeMounts(deploymentName string, cr *apiv1alpha1.Ast
 	return ret
 }
 
-func getAstarteGenericAPIEnvVars(deploymentName string, cr *apiv1alpha1.Astarte, api apiv1alpha1.AstarteGenericAPISpec, component apiv1alpha1.AstarteComponent) []v1.EnvVar {
-	ret := getAstarteCommonEnvVars(deploymentName, cr, component)
+func getAstarteGenericAPIEnvVars(deploymentName string, cr *apiv1alpha2.Astarte, api apiv1alpha2.AstarteGenericAPISpec, component apiv1alpha2.AstarteComponent) []v1.EnvVar {
+	ret := getAstarteCommonEnvVars(deploymentName, cr, component, api.AstartePodSecuritySpec)
 	// Add Port
 	ret = append(ret, v1.EnvVar{
 		Name:  strings.ToUpper(component.String()) + "_PORT",
@@ -194,7 +215,7 @@ func getAstarteGenericAPIEnvVars(deploymentName string, cr *apiv1alpha1.Astarte,
 
 	// Depending on the component, we might need to add some more stuff.
 	switch component {
-	case apiv1alpha1.AppEngineAPI:
+	case apiv1alpha2.AppEngineAPI:
 		// Add Cassandra Nodes, AMQP information and Max results count
 		rabbitMQHost, rabbitMQPort := misc.GetRabbitMQHostnameAndPort(cr)
 		userCredentialsSecretName, userCredentialsSecretUsernameKey, userCredentialsSecretPasswordKey := misc.GetRabbitMQUserCredentialsSecret(cr)
@@ -230,7 +251,7 @@ func getAstarteGenericAPIEnvVars(deploymentName string, cr *apiv1alpha1.Astarte,
 				}},
 			},
 		)
-	case apiv1alpha1.HousekeepingAPI:
+	case apiv1alpha2.HousekeepingAPI:
 		// Add Public Key Information
 		ret = append(ret, v1.EnvVar{
 			Name:  "HOUSEKEEPING_API_JWT_PUBLIC_KEY_PATH",
@@ -241,15 +262,15 @@ func getAstarteGenericAPIEnvVars(deploymentName string, cr *apiv1alpha1.Astarte,
 	return ret
 }
 
-func getAstarteAPIProbe(cr *apiv1alpha1.Astarte, api apiv1alpha1.AstarteGenericAPISpec, component apiv1alpha1.AstarteComponent) *v1.Probe {
+func getAstarteAPIProbe(cr *apiv1alpha2.Astarte, api apiv1alpha2.AstarteGenericAPISpec, component apiv1alpha2.AstarteComponent) *v1.Probe {
 	// Parse the version first
-	v := getSemanticVersionForAstarteComponent(cr, api.GenericClusteredResource.Version)
+	v := getSemanticVersionForAstarteComponent(cr, api.Version)
 	checkVersion, _ := v.SetPrerelease("")
 	constraint, _ := semver.NewConstraint("< 0.11.0")
 
 	if constraint.Check(&checkVersion) {
 		// Only Housekeeping has a health check in 0.10.x
-		if component != apiv1alpha1.HousekeepingAPI {
+		if component != apiv1alpha2.HousekeepingAPI {
 			return nil
 		}
 
@@ -259,7 +280,7 @@ func getAstarteAPIProbe(cr *apiv1alpha1.Astarte, api apiv1alpha1.AstarteGenericA
 	// 0.11 Beta 1 doesn't have Realm Management and Pairing API probes
 	constraint, _ = semver.NewConstraint("<= 0.11.0-beta.1")
 	if constraint.Check(v) {
-		if component == apiv1alpha1.RealmManagementAPI || component == apiv1alpha1.PairingAPI {
+		if component == apiv1alpha2.RealmManagementAPI || component == apiv1alpha2.PairingAPI {
 			return nil
 		}
 	}
@@ -5,7 +5,7 @@ import (
 	"strconv"
 
 	semver "github.com/Masterminds/semver/v3"
-	apiv1alpha1 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha1"
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
 	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
 	"github.com/openlyinc/pointy"
 	appsv1 "k8s.io/api/apps/v1"
@@ -18,7 +18,7 @@ import (
 )
 
 // EnsureAstarteGenericBackend reconciles any component compatible with AstarteGenericClusteredResource
-func EnsureAstarteGenericBackend(cr *apiv1alpha1.Astarte, backend apiv1alpha1.AstarteGenericClusteredResource, component apiv1alpha1.AstarteComponent, c client.Client, scheme *runtime.Scheme) error {
+func EnsureAstarteGenericBackend(ctx context.Context, cr *apiv1alpha2.Astarte, backend apiv1alpha2.AstarteGenericClusteredResource, component apiv1alpha2.AstarteComponent, c client.Client, scheme *runtime.Scheme) error {
 	reqLogger := log.WithValues("Request.Namespace", cr.Namespace, "Request.Name", cr.Name, "Astarte.Component", component)
 	deploymentName := cr.Name + "-" + component.DashedString()
 	labels := map[string]string{
@@ -28,27 +28,35 @@ func EnsureAstarteGenericBackend(cr *apiv1alpha1.Astarte, backend apiv1alpha1.As
 	}
 	matchLabels := map[string]string{"app": deploymentName}
 
+	// The Disruption Budget follows the replicas of the Component, and goes away together with it
+	if err := ensurePodDisruptionBudget(ctx, deploymentName, matchLabels, backend, cr, c, scheme); err != nil {
+		return err
+	}
+
 	// Ok. Shall we deploy?
 	if !pointy.BoolValue(backend.Deploy, true) {
 		reqLogger.V(1).Info("Skipping Astarte Component Deployment")
-		// Before returning - check if we shall clean up the Deployment.
-		// It is the only thing actually requiring resources, the rest will be cleaned up eventually when the
-		// Astarte resource is deleted.
+		// Before returning - clean up everything in the Component's inventory. The Deployment is looked up by
+		// name too, as it might predate the inventory.
 		theDeployment := &appsv1.Deployment{}
-		err := c.Get(context.TODO(), types.NamespacedName{Name: deploymentName, Namespace: cr.Namespace}, theDeployment)
+		err := c.Get(ctx, types.NamespacedName{Name: deploymentName, Namespace: cr.Namespace}, theDeployment)
 		if err == nil {
 			reqLogger.Info("Deleting previously existing Component Deployment, which is no longer needed")
-			if err = c.Delete(context.TODO(), theDeployment); err != nil {
+			if err := misc.DeleteOwnedResource(ctx, cr, theDeployment, c); err != nil {
 				return err
 			}
 		}
 
+		if err := pruneComponentInventory(ctx, component.DashedString(), cr, c); err != nil {
+			return err
+		}
+
 		// That would be all for today.
 		return nil
 	}
 
 	// First of all, check if we need to regenerate the cookie.
-	if err := ensureErlangCookieSecret(deploymentName+"-cookie", cr, c, scheme); err != nil {
+	if err := ensureErlangCookieSecret(ctx, deploymentName+"-cookie", component.DashedString(), cr, c, scheme); err != nil {
 		return err
 	}
 
@@ -58,16 +66,19 @@ func EnsureAstarteGenericBackend(cr *apiv1alpha1.Astarte, backend apiv1alpha1.As
 		},
 		Strategy: cr.Spec.DeploymentStrategy,
 		Template: v1.PodTemplateSpec{
-			ObjectMeta: metav1.ObjectMeta{
-				Labels: labels,
-			},
-			Spec: getAstarteGenericBackendPodSpec(deploymentName, cr, backend, component),
+			ObjectMeta: getPodTemplateObjectMeta(labels, backend.AstartePodSchedulingSpec, getHardenedPodSecuritySpec(backend.AstartePodSecuritySpec)),
+			Spec:       getAstarteGenericBackendPodSpec(deploymentName, cr, backend, component),
 		},
 	}
 
+	// Roll the Pods whenever the configuration or credentials they consume change
+	if err := setConfigurationChecksums(ctx, &deploymentSpec.Template, cr, c); err != nil {
+		return err
+	}
+
 	// Build the Deployment
 	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: deploymentName, Namespace: cr.Namespace}}
-	result, err := controllerutil.CreateOrUpdate(context.TODO(), c, deployment, func() error {
+	result, err := applyComponentObject(ctx, component.DashedString(), cr, c, scheme, deployment, func() error {
 		if err := controllerutil.SetControllerReference(cr, deployment, scheme); err != nil {
 			return err
 		}
@@ -83,11 +94,11 @@ func EnsureAstarteGenericBackend(cr *apiv1alpha1.Astarte, backend apiv1alpha1.As
 		return err
 	}
 
-	logCreateOrUpdateOperationResult(result, cr, deployment)
+	logCreateOrUpdateOperationResult(ctx, result, cr, deployment)
 	return nil
 }
 
-func getAstarteGenericBackendPodSpec(deploymentName string, cr *apiv1alpha1.Astarte, backend apiv1alpha1.AstarteGenericClusteredResource, component apiv1alpha1.AstarteComponent) v1.PodSpec {
+func getAstarteGenericBackendPodSpec(deploymentName string, cr *apiv1alpha2.Astarte, backend apiv1alpha2.AstarteGenericClusteredResource, component apiv1alpha2.AstarteComponent) v1.PodSpec {
 	ps := v1.PodSpec{
 		TerminationGracePeriodSeconds: pointy.Int64(30),
 		ImagePullSecrets:              cr.Spec.ImagePullSecrets,
@@ -105,11 +116,15 @@ func getAstarteGenericBackendPodSpec(deploymentName string, cr *apiv1alpha1.Asta
 		Volumes: getAstarteGenericBackendVolumes(deploymentName, cr, backend, component),
 	}
 
+	applyPodSchedulingSpec(&ps, backend.AstartePodSchedulingSpec)
+	applyPodExtensionsSpec(&ps, backend.AstartePodExtensionsSpec)
+	applyPodSecuritySpec(&ps, getHardenedPodSecuritySpec(backend.AstartePodSecuritySpec))
+
 	return ps
 }
 
-func getAstarteGenericBackendVolumes(deploymentName string, cr *apiv1alpha1.Astarte, backend apiv1alpha1.AstarteGenericClusteredResource, component apiv1alpha1.AstarteComponent) []v1.Volume {
-	ret := getAstarteCommonVolumes(cr)
+func getAstarteGenericBackendVolumes(deploymentName string, cr *apiv1alpha2.Astarte, backend apiv1alpha2.AstarteGenericClusteredResource, component apiv1alpha2.AstarteComponent) []v1.Volume {
+	ret := getAstarteCommonVolumes(cr, backend.AstartePodSecuritySpec)
 
 	// Depending on the component, we might need to add some more stuff.
 	switch component {
@@ -118,8 +133,8 @@ func getAstarteGenericBackendVolumes(deploymentName string, cr *apiv1alpha1.Asta
 	return ret
 }
 
-func getAstarteGenericBackendVolumeMounts(deploymentName string, cr *apiv1alpha1.Astarte, backend apiv1alpha1.AstarteGenericClusteredResource, component apiv1alpha1.AstarteComponent) []v1.VolumeMount {
-	ret := getAstarteCommonVolumeMounts()
+func getAstarteGenericBackendVolumeMounts(deploymentName string, cr *apiv1alpha2.Astarte, backend apiv1alpha2.AstarteGenericClusteredResource, component apiv1alpha2.AstarteComponent) []v1.VolumeMount {
+	ret := getAstarteCommonVolumeMounts(backend.AstartePodSecuritySpec)
 
 	// Depending on the component, we might need to add some more stuff.
 	switch component {
@@ -128,8 +143,8 @@ func getAstarteGenericBackendVolumeMounts(deploymentName string, cr *apiv1alpha1
 	return ret
 }
 
-func getAstarteGenericBackendEnvVars(deploymentName string, cr *apiv1alpha1.Astarte, backend apiv1alpha1.AstarteGenericClusteredResource, component apiv1alpha1.AstarteComponent) []v1.EnvVar {
-	ret := getAstarteCommonEnvVars(deploymentName, cr, component)
+func getAstarteGenericBackendEnvVars(deploymentName string, cr *apiv1alpha2.Astarte, backend apiv1alpha2.AstarteGenericClusteredResource, component apiv1alpha2.AstarteComponent) []v1.EnvVar {
+	ret := getAstarteCommonEnvVars(deploymentName, cr, component, backend.AstartePodSecuritySpec)
 	// Add Cassandra Nodes
 	ret = append(ret, v1.EnvVar{
 		Name:  "ASTARTE_CASSANDRA_NODES",
@@ -138,7 +153,7 @@ func getAstarteGenericBackendEnvVars(deploymentName string, cr *apiv1alpha1.Asta
 
 	// Depending on the component, we might need to add some more stuff.
 	switch component {
-	case apiv1alpha1.Pairing:
+	case apiv1alpha2.Pairing:
 		ret = append(ret,
 			v1.EnvVar{
 				Name:  "PAIRING_CFSSL_URL",
@@ -148,7 +163,7 @@ func getAstarteGenericBackendEnvVars(deploymentName string, cr *apiv1alpha1.Asta
 				Name:  "PAIRING_BROKER_URL",
 				Value: misc.GetVerneMQBrokerURL(cr),
 			})
-	case apiv1alpha1.DataUpdaterPlant:
+	case apiv1alpha2.DataUpdaterPlant:
 		rabbitMQHost, rabbitMQPort := misc.GetRabbitMQHostnameAndPort(cr)
 		userCredentialsSecretName, userCredentialsSecretUsernameKey, userCredentialsSecretPasswordKey := misc.GetRabbitMQUserCredentialsSecret(cr)
 		ret = append(ret,
@@ -219,7 +234,7 @@ func getAstarteGenericBackendEnvVars(deploymentName string, cr *apiv1alpha1.Asta
 					Value: strconv.Itoa(getDataQueueCount(cr) - 1),
 				})
 		}
-	case apiv1alpha1.TriggerEngine:
+	case apiv1alpha2.TriggerEngine:
 		rabbitMQHost, rabbitMQPort := misc.GetRabbitMQHostnameAndPort(cr)
 		userCredentialsSecretName, userCredentialsSecretUsernameKey, userCredentialsSecretPasswordKey := misc.GetRabbitMQUserCredentialsSecret(cr)
 		ret = append(ret,
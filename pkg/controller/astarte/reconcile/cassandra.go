@@ -10,8 +10,9 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	semver "github.com/Masterminds/semver/v3"
-	apiv1alpha1 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha1"
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
 	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/controller/astarte/deps"
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
 	"github.com/openlyinc/pointy"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
@@ -23,7 +24,7 @@ import (
 )
 
 // EnsureCassandra reconciles Cassandra
-func EnsureCassandra(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Scheme) error {
+func EnsureCassandra(ctx context.Context, cr *apiv1alpha2.Astarte, c client.Client, scheme *runtime.Scheme) error {
 	//reqLogger := log.WithValues("Request.Namespace", cr.Namespace, "Request.Name", cr.Name)
 	statefulSetName := cr.Name + "-cassandra"
 	labels := map[string]string{"app": statefulSetName}
@@ -33,28 +34,36 @@ func EnsureCassandra(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.S
 		return err
 	}
 
+	// The Disruption Budget follows the replicas of the Component, and goes away together with it
+	if err := ensurePodDisruptionBudget(ctx, statefulSetName, labels, cr.Spec.Cassandra.AstarteGenericClusteredResource, cr, c, scheme); err != nil {
+		return err
+	}
+
 	// Ok. Shall we deploy?
-	if !pointy.BoolValue(cr.Spec.Cassandra.GenericClusteredResource.Deploy, true) {
+	if !pointy.BoolValue(cr.Spec.Cassandra.Deploy, true) {
 		log.Info("Skipping Cassandra Deployment")
-		// Before returning - check if we shall clean up the StatefulSet.
-		// It is the only thing actually requiring resources, the rest will be cleaned up eventually when the
-		// Astarte resource is deleted.
+		// Before returning - clean up everything in the Component's inventory. The StatefulSet is looked up by
+		// name too, as it might predate the inventory.
 		theStatefulSet := &appsv1.StatefulSet{}
-		err := c.Get(context.TODO(), types.NamespacedName{Name: statefulSetName, Namespace: cr.Namespace}, theStatefulSet)
+		err := c.Get(ctx, types.NamespacedName{Name: statefulSetName, Namespace: cr.Namespace}, theStatefulSet)
 		if err == nil {
 			log.Info("Deleting previously existing Cassandra StatefulSet, which is no longer needed")
-			if err = c.Delete(context.TODO(), theStatefulSet); err != nil {
+			if err := misc.DeleteOwnedResource(ctx, cr, theStatefulSet, c); err != nil {
 				return err
 			}
 		}
 
+		if err := pruneComponentInventory(ctx, "cassandra", cr, c); err != nil {
+			return err
+		}
+
 		// That would be all for today.
 		return nil
 	}
 
 	// Good. Now, reconcile the service first of all.
 	service := &v1.Service{ObjectMeta: metav1.ObjectMeta{Name: statefulSetName, Namespace: cr.Namespace}}
-	if result, err := controllerutil.CreateOrUpdate(context.TODO(), c, service, func() error {
+	if result, err := applyComponentObject(ctx, "cassandra", cr, c, scheme, service, func() error {
 		if err := controllerutil.SetControllerReference(cr, service, scheme); err != nil {
 			return err
 		}
@@ -72,7 +81,7 @@ func EnsureCassandra(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.S
 		service.Spec.Selector = labels
 		return nil
 	}); err == nil {
-		logCreateOrUpdateOperationResult(result, cr, service)
+		logCreateOrUpdateOperationResult(ctx, result, cr, service)
 	} else {
 		return err
 	}
@@ -88,27 +97,30 @@ func EnsureCassandra(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.S
 			MatchLabels: labels,
 		},
 		Template: v1.PodTemplateSpec{
-			ObjectMeta: metav1.ObjectMeta{
-				Labels: labels,
-			},
-			Spec: getCassandraPodSpec(statefulSetName, dataVolumeName, cr),
+			ObjectMeta: getPodTemplateObjectMeta(labels, cr.Spec.Cassandra.AstartePodSchedulingSpec, cr.Spec.Cassandra.AstartePodSecuritySpec),
+			Spec:       getCassandraPodSpec(statefulSetName, dataVolumeName, cr),
 		},
 	}
 
+	// Roll the Pods whenever the configuration or credentials they consume change
+	if err := setConfigurationChecksums(ctx, &statefulSetSpec.Template, cr, c); err != nil {
+		return err
+	}
+
 	if persistentVolumeClaim != nil {
 		statefulSetSpec.VolumeClaimTemplates = []v1.PersistentVolumeClaim{*persistentVolumeClaim}
 	}
 
 	// Build the StatefulSet
 	cassandraStatefulSet := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: statefulSetName, Namespace: cr.Namespace}}
-	result, err := controllerutil.CreateOrUpdate(context.TODO(), c, cassandraStatefulSet, func() error {
+	result, err := applyComponentObject(ctx, "cassandra", cr, c, scheme, cassandraStatefulSet, func() error {
 		if err := controllerutil.SetControllerReference(cr, cassandraStatefulSet, scheme); err != nil {
 			return err
 		}
 
 		// Assign the Spec.
 		cassandraStatefulSet.Spec = statefulSetSpec
-		cassandraStatefulSet.Spec.Replicas = cr.Spec.Cassandra.GenericClusteredResource.Replicas
+		cassandraStatefulSet.Spec.Replicas = cr.Spec.Cassandra.Replicas
 
 		return nil
 	})
@@ -116,13 +128,13 @@ func EnsureCassandra(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.S
 		return err
 	}
 
-	logCreateOrUpdateOperationResult(result, cr, service)
+	logCreateOrUpdateOperationResult(ctx, result, cr, service)
 	return nil
 }
 
-func validateCassandraDefinition(cassandra apiv1alpha1.AstarteCassandraSpec) error {
-	if !pointy.BoolValue(cassandra.GenericClusteredResource.Deploy, true) && cassandra.Nodes == "" {
-		return errors.New("When not deploying Cassandra, the 'nodes' must be specified")
+func validateCassandraDefinition(cassandra apiv1alpha2.AstarteCassandraSpec) error {
+	if !pointy.BoolValue(cassandra.Deploy, true) && cassandra.Nodes == "" {
+		return NewInvalidSpecError(errors.New("When not deploying Cassandra, the 'nodes' must be specified"))
 	}
 
 	// All is good.
@@ -140,7 +152,7 @@ func getCassandraProbe() *v1.Probe {
 	}
 }
 
-func getCassandraEnvVars(statefulSetName string, cr *apiv1alpha1.Astarte) []v1.EnvVar {
+func getCassandraEnvVars(statefulSetName string, cr *apiv1alpha2.Astarte) []v1.EnvVar {
 	maxHeapSize := "1024M"
 	heapNewSize := "256M"
 	if cr.Spec.Cassandra.MaxHeapSize != "" {
@@ -188,13 +200,13 @@ func getCassandraEnvVars(statefulSetName string, cr *apiv1alpha1.Astarte) []v1.E
 	return envVars
 }
 
-func getCassandraPodSpec(statefulSetName, dataVolumeName string, cr *apiv1alpha1.Astarte) v1.PodSpec {
+func getCassandraPodSpec(statefulSetName, dataVolumeName string, cr *apiv1alpha2.Astarte) v1.PodSpec {
 	astarteVersion, _ := semver.NewVersion(cr.Spec.Version)
 	ps := v1.PodSpec{
 		// Give it a lot of time to terminate to drain the node.
 		TerminationGracePeriodSeconds: pointy.Int64(1800),
 		ImagePullSecrets:              cr.Spec.ImagePullSecrets,
-		Affinity:                      getAffinityForClusteredResource(statefulSetName, cr.Spec.Cassandra.GenericClusteredResource),
+		Affinity:                      getAffinityForClusteredResource(statefulSetName, cr.Spec.Cassandra.AstarteGenericClusteredResource),
 		Containers: []v1.Container{
 			v1.Container{
 				Name: "cassandra",
@@ -204,7 +216,7 @@ func getCassandraPodSpec(statefulSetName, dataVolumeName string, cr *apiv1alpha1
 						MountPath: "/cassandra_data",
 					},
 				},
-				Image:           getImageForClusteredResource("gcr.io/google-samples/cassandra", deps.GetDefaultVersionForCassandra(astarteVersion), cr.Spec.Cassandra.GenericClusteredResource),
+				Image:           getImageForClusteredResource("gcr.io/google-samples/cassandra", deps.GetDefaultVersionForCassandra(astarteVersion), cr.Spec.Cassandra.AstarteGenericClusteredResource),
 				ImagePullPolicy: getImagePullPolicy(cr),
 				Ports: []v1.ContainerPort{
 					v1.ContainerPort{Name: "intra-node", ContainerPort: 7000},
@@ -213,7 +225,7 @@ func getCassandraPodSpec(statefulSetName, dataVolumeName string, cr *apiv1alpha1
 					v1.ContainerPort{Name: "cql", ContainerPort: 9042},
 				},
 				ReadinessProbe: getCassandraProbe(),
-				Resources:      cr.Spec.Cassandra.GenericClusteredResource.Resources,
+				Resources:      cr.Spec.Cassandra.Resources,
 				Env:            getCassandraEnvVars(statefulSetName, cr),
 				SecurityContext: &v1.SecurityContext{
 					Capabilities: &v1.Capabilities{Add: []v1.Capability{"IPC_LOCK"}},
@@ -223,12 +235,16 @@ func getCassandraPodSpec(statefulSetName, dataVolumeName string, cr *apiv1alpha1
 		},
 	}
 
+	applyPodSchedulingSpec(&ps, cr.Spec.Cassandra.AstartePodSchedulingSpec)
+	applyPodExtensionsSpec(&ps, cr.Spec.Cassandra.AstartePodExtensionsSpec)
+	applyPodSecuritySpec(&ps, cr.Spec.Cassandra.AstartePodSecuritySpec)
+
 	return ps
 }
 
 // This stuff is useful for other components which need to interact with Cassandra
-func getCassandraNodes(cr *apiv1alpha1.Astarte) string {
-	replicas := cr.Spec.Cassandra.GenericClusteredResource.Replicas
+func getCassandraNodes(cr *apiv1alpha2.Astarte) string {
+	replicas := cr.Spec.Cassandra.Replicas
 	if cr.Spec.Cassandra.Nodes != "" {
 		return cr.Spec.Cassandra.Nodes
 	}
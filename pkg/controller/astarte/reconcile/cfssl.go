@@ -7,8 +7,9 @@ import (
 	"fmt"
 
 	semver "github.com/Masterminds/semver/v3"
-	apiv1alpha1 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha1"
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
 	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/controller/astarte/deps"
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
 	"github.com/openlyinc/pointy"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
@@ -22,7 +23,7 @@ import (
 )
 
 // EnsureCFSSL reconciles CFSSL
-func EnsureCFSSL(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Scheme) error {
+func EnsureCFSSL(ctx context.Context, cr *apiv1alpha2.Astarte, c client.Client, scheme *runtime.Scheme) error {
 	//reqLogger := log.WithValues("Request.Namespace", cr.Namespace, "Request.Name", cr.Name)
 	statefulSetName := cr.Name + "-cfssl"
 	labels := map[string]string{"app": statefulSetName}
@@ -35,25 +36,28 @@ func EnsureCFSSL(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Schem
 	// Ok. Shall we deploy?
 	if !pointy.BoolValue(cr.Spec.CFSSL.Deploy, true) {
 		log.Info("Skipping CFSSL Deployment")
-		// Before returning - check if we shall clean up the StatefulSet.
-		// It is the only thing actually requiring resources, the rest will be cleaned up eventually when the
-		// Astarte resource is deleted.
+		// Before returning - clean up everything in the Component's inventory. The StatefulSet is looked up by
+		// name too, as it might predate the inventory.
 		theStatefulSet := &appsv1.StatefulSet{}
-		err := c.Get(context.TODO(), types.NamespacedName{Name: statefulSetName, Namespace: cr.Namespace}, theStatefulSet)
+		err := c.Get(ctx, types.NamespacedName{Name: statefulSetName, Namespace: cr.Namespace}, theStatefulSet)
 		if err == nil {
 			log.Info("Deleting previously existing CFSSL StatefulSet, which is no longer needed")
-			if err = c.Delete(context.TODO(), theStatefulSet); err != nil {
+			if err := misc.DeleteOwnedResource(ctx, cr, theStatefulSet, c); err != nil {
 				return err
 			}
 		}
 
+		if err := pruneComponentInventory(ctx, "cfssl", cr, c); err != nil {
+			return err
+		}
+
 		// That would be all for today.
 		return nil
 	}
 
 	// Good. Now, reconcile the service first of all.
 	service := &v1.Service{ObjectMeta: metav1.ObjectMeta{Name: statefulSetName, Namespace: cr.Namespace}}
-	if result, err := controllerutil.CreateOrUpdate(context.TODO(), c, service, func() error {
+	if result, err := applyComponentObject(ctx, "cfssl", cr, c, scheme, service, func() error {
 		if err := controllerutil.SetControllerReference(cr, service, scheme); err != nil {
 			return err
 		}
@@ -70,7 +74,7 @@ func EnsureCFSSL(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Schem
 		service.Spec.Selector = labels
 		return nil
 	}); err == nil {
-		logCreateOrUpdateOperationResult(result, cr, service)
+		logCreateOrUpdateOperationResult(ctx, result, cr, service)
 	} else {
 		return err
 	}
@@ -80,7 +84,7 @@ func EnsureCFSSL(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Schem
 	if err != nil {
 		return err
 	}
-	if _, err := reconcileConfigMap(statefulSetName+"-config", configMap, cr, c, scheme); err != nil {
+	if _, err := reconcileConfigMap(ctx, statefulSetName+"-config", "cfssl", configMap, cr, c, scheme); err != nil {
 		return err
 	}
 
@@ -95,20 +99,23 @@ func EnsureCFSSL(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Schem
 			MatchLabels: labels,
 		},
 		Template: v1.PodTemplateSpec{
-			ObjectMeta: metav1.ObjectMeta{
-				Labels: labels,
-			},
-			Spec: getCFSSLPodSpec(statefulSetName, dataVolumeName, cr),
+			ObjectMeta: getPodTemplateObjectMeta(labels, cr.Spec.CFSSL.AstartePodSchedulingSpec, cr.Spec.CFSSL.AstartePodSecuritySpec),
+			Spec:       getCFSSLPodSpec(statefulSetName, dataVolumeName, cr),
 		},
 	}
 
+	// Roll the Pods whenever the configuration or credentials they consume change
+	if err := setConfigurationChecksums(ctx, &statefulSetSpec.Template, cr, c); err != nil {
+		return err
+	}
+
 	if persistentVolumeClaim != nil {
 		statefulSetSpec.VolumeClaimTemplates = []v1.PersistentVolumeClaim{*persistentVolumeClaim}
 	}
 
 	// Build the StatefulSet
 	cfsslStatefulSet := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: statefulSetName, Namespace: cr.Namespace}}
-	result, err := controllerutil.CreateOrUpdate(context.TODO(), c, cfsslStatefulSet, func() error {
+	result, err := applyComponentObject(ctx, "cfssl", cr, c, scheme, cfsslStatefulSet, func() error {
 		if err := controllerutil.SetControllerReference(cr, cfsslStatefulSet, scheme); err != nil {
 			return err
 		}
@@ -123,20 +130,20 @@ func EnsureCFSSL(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Schem
 		return err
 	}
 
-	logCreateOrUpdateOperationResult(result, cr, service)
+	logCreateOrUpdateOperationResult(ctx, result, cr, service)
 	return nil
 }
 
-func validateCFSSLDefinition(cfssl apiv1alpha1.AstarteCFSSLSpec) error {
+func validateCFSSLDefinition(cfssl apiv1alpha2.AstarteCFSSLSpec) error {
 	if !pointy.BoolValue(cfssl.Deploy, true) && cfssl.URL == "" {
-		return errors.New("When not deploying CFSSL, the 'url' must be specified")
+		return NewInvalidSpecError(errors.New("When not deploying CFSSL, the 'url' must be specified"))
 	}
 
 	// All is good.
 	return nil
 }
 
-func getCFSSLProbe(cr *apiv1alpha1.Astarte) *v1.Probe {
+func getCFSSLProbe(cr *apiv1alpha2.Astarte) *v1.Probe {
 	c, _ := semver.NewConstraint("< 0.11.0")
 	ver, _ := semver.NewVersion(getVersionForAstarteComponent(cr, cr.Spec.CFSSL.Version))
 	checkVersion, _ := ver.SetPrerelease("")
@@ -162,7 +169,7 @@ func getCFSSLProbe(cr *apiv1alpha1.Astarte) *v1.Probe {
 	}
 }
 
-func getCFSSLPodSpec(statefulSetName, dataVolumeName string, cr *apiv1alpha1.Astarte) v1.PodSpec {
+func getCFSSLPodSpec(statefulSetName, dataVolumeName string, cr *apiv1alpha2.Astarte) v1.PodSpec {
 	// Defaults to the custom image built in Astarte
 	astarteVersion, _ := semver.NewVersion(cr.Spec.Version)
 	cfsslImage := getAstarteImageFromChannel("cfssl", deps.GetDefaultVersionForCFSSL(astarteVersion), cr)
@@ -210,10 +217,14 @@ func getCFSSLPodSpec(statefulSetName, dataVolumeName string, cr *apiv1alpha1.Ast
 		},
 	}
 
+	applyPodSchedulingSpec(&ps, cr.Spec.CFSSL.AstartePodSchedulingSpec)
+	applyPodExtensionsSpec(&ps, cr.Spec.CFSSL.AstartePodExtensionsSpec)
+	applyPodSecuritySpec(&ps, cr.Spec.CFSSL.AstartePodSecuritySpec)
+
 	return ps
 }
 
-func getCFSSLConfigMapData(statefulSetName string, cr *apiv1alpha1.Astarte) (map[string]string, error) {
+func getCFSSLConfigMapData(statefulSetName string, cr *apiv1alpha2.Astarte) (map[string]string, error) {
 	// First of all, build the default maps.
 	dbConfigDefaultJSON := `{"data_source": "/data/certs.db", "driver": "sqlite3"}`
 	caRootConfigDefaultJSON := `{"signing": {"default": {"usages": ["digital signature", "cert sign", "crl sign", "signing"], "ca_constraint": {"max_path_len_zero": true, "max_path_len": 0, "is_ca": true}, "expiry": "2190h"}}}`
@@ -297,7 +308,7 @@ func getCFSSLConfigMapData(statefulSetName string, cr *apiv1alpha1.Astarte) (map
 }
 
 // This stuff is useful for other components which need to interact with CFSSL
-func getCFSSLURL(cr *apiv1alpha1.Astarte) string {
+func getCFSSLURL(cr *apiv1alpha2.Astarte) string {
 	if cr.Spec.CFSSL.URL != "" {
 		return cr.Spec.CFSSL.URL
 	}
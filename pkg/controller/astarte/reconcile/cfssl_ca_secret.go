@@ -3,7 +3,8 @@ package reconcile
 import (
 	"context"
 
-	apiv1alpha1 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha1"
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
 	"github.com/openlyinc/pointy"
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
@@ -16,31 +17,31 @@ import (
 )
 
 // EnsureCFSSLCASecret reconciles CFSSL's CA Secret
-func EnsureCFSSLCASecret(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Scheme) error {
+func EnsureCFSSLCASecret(ctx context.Context, cr *apiv1alpha2.Astarte, c client.Client, scheme *runtime.Scheme) error {
 	jobName := cr.Name + "-cfssl-ca-secret-job"
 	secretName := cr.Name + "-cfssl-ca"
 	// First of all, ensure we have the right roles.
 	if pointy.BoolValue(cr.Spec.RBAC, true) {
-		if err := reconcileStandardRBACForClusteringForApp(jobName, getCFSSLCAJobPolicyRules(), cr, c, scheme); err != nil {
+		if err := reconcileStandardRBACForClusteringForApp(ctx, jobName, "", getCFSSLCAJobPolicyRules(), cr, c, scheme); err != nil {
 			return err
 		}
 	}
 	serviceAccountName := jobName
-	if pointy.BoolValue(cr.Spec.RBAC, false) {
+	if !pointy.BoolValue(cr.Spec.RBAC, true) {
 		serviceAccountName = ""
 	}
 
 	// Now - is the secret there?
 	secretThere := false
 	theSecret := &v1.Secret{}
-	if err := c.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: cr.Namespace}, theSecret); err == nil {
+	if err := c.Get(ctx, types.NamespacedName{Name: secretName, Namespace: cr.Namespace}, theSecret); err == nil {
 		// The secret is on.
 		secretThere = true
 	}
 	// Is the Job up and running?
 	jobThere := false
 	theJob := &batchv1.Job{}
-	if err := c.Get(context.TODO(), types.NamespacedName{Name: jobName, Namespace: cr.Namespace}, theJob); err == nil {
+	if err := c.Get(ctx, types.NamespacedName{Name: jobName, Namespace: cr.Namespace}, theJob); err == nil {
 		// The Job is on.
 		jobThere = true
 	}
@@ -51,7 +52,7 @@ func EnsureCFSSLCASecret(cr *apiv1alpha1.Astarte, c client.Client, scheme *runti
 	case secretThere && jobThere:
 		// Delete the Job.
 		reqLogger.Info("Deleting stale CFSSL CA Job")
-		if err := c.Delete(context.TODO(), theJob); err != nil {
+		if err := c.Delete(ctx, theJob); err != nil {
 			return err
 		}
 	case !secretThere && !jobThere:
@@ -90,9 +91,12 @@ func EnsureCFSSLCASecret(cr *apiv1alpha1.Astarte, c client.Client, scheme *runti
 		if err := controllerutil.SetControllerReference(cr, job, scheme); err != nil {
 			return err
 		}
-		if err := c.Create(context.TODO(), job); err != nil {
+		if err := c.Create(ctx, job); err != nil {
 			return err
 		}
+		if !misc.IsPlanning(cr) {
+			misc.RecordEvent(ctx, cr, misc.EventReasonCAJobCreated, "Created Job %s to store CFSSL's CA in Secret %s", jobName, secretName)
+		}
 	}
 
 	return nil
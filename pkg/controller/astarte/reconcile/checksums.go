@@ -0,0 +1,168 @@
+package reconcile
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"sort"
+
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Pod template annotations holding the checksums of the content of the ConfigMaps and Secrets consumed by the Pods.
+// As they change together with the content, Pods are rolled out whenever their configuration or credentials change.
+const (
+	configMapsChecksumAnnotation = "api.astarte-platform.org/configmaps-checksum"
+	secretsChecksumAnnotation    = "api.astarte-platform.org/secrets-checksum"
+)
+
+// setConfigurationChecksums stamps the checksums of all ConfigMaps and Secrets consumed by template on its annotations.
+// Objects which don't exist yet are accounted for too, so that Pods are rolled out once they are created.
+func setConfigurationChecksums(ctx context.Context, template *v1.PodTemplateSpec, cr *apiv1alpha2.Astarte, c client.Client) error {
+	configMapNames, secretNames := getPodSpecConfigurationReferences(template.Spec)
+
+	annotations := map[string]string{}
+	for k, v := range template.GetAnnotations() {
+		annotations[k] = v
+	}
+
+	if len(configMapNames) > 0 {
+		checksum, err := computeConfigurationChecksum(configMapNames, func(name string, h hash.Hash) error {
+			configMap := &v1.ConfigMap{}
+			if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: cr.Namespace}, configMap); err != nil {
+				return err
+			}
+			for _, k := range getSortedDataKeys(configMap.Data) {
+				fmt.Fprintf(h, "%s=%s\n", k, configMap.Data[k])
+			}
+			for _, k := range getSortedBinaryDataKeys(configMap.BinaryData) {
+				fmt.Fprintf(h, "%s=%x\n", k, configMap.BinaryData[k])
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		annotations[configMapsChecksumAnnotation] = checksum
+	}
+
+	if len(secretNames) > 0 {
+		checksum, err := computeConfigurationChecksum(secretNames, func(name string, h hash.Hash) error {
+			secret := &v1.Secret{}
+			if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: cr.Namespace}, secret); err != nil {
+				return err
+			}
+			for _, k := range getSortedBinaryDataKeys(secret.Data) {
+				fmt.Fprintf(h, "%s=%x\n", k, secret.Data[k])
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		annotations[secretsChecksumAnnotation] = checksum
+	}
+
+	template.SetAnnotations(annotations)
+	return nil
+}
+
+// computeConfigurationChecksum hashes the content of all named objects, written to the hash by writeContent.
+// Missing objects are hashed as such.
+func computeConfigurationChecksum(names []string, writeContent func(name string, h hash.Hash) error) (string, error) {
+	h := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(h, "%s\n", name)
+		if err := writeContent(name, h); err != nil {
+			if !kerrors.IsNotFound(err) {
+				return "", err
+			}
+			fmt.Fprintf(h, "missing\n")
+		}
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// getPodSpecConfigurationReferences returns the sorted names of all ConfigMaps and Secrets consumed by a PodSpec,
+// either through Volumes or through environment variables
+func getPodSpecConfigurationReferences(ps v1.PodSpec) ([]string, []string) {
+	configMaps, secrets := map[string]bool{}, map[string]bool{}
+
+	for _, volume := range ps.Volumes {
+		if volume.ConfigMap != nil {
+			configMaps[volume.ConfigMap.Name] = true
+		}
+		if volume.Secret != nil {
+			secrets[volume.Secret.SecretName] = true
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					configMaps[source.ConfigMap.Name] = true
+				}
+				if source.Secret != nil {
+					secrets[source.Secret.Name] = true
+				}
+			}
+		}
+	}
+
+	for _, container := range append(append([]v1.Container{}, ps.InitContainers...), ps.Containers...) {
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				configMaps[env.ValueFrom.ConfigMapKeyRef.Name] = true
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				secrets[env.ValueFrom.SecretKeyRef.Name] = true
+			}
+		}
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				configMaps[envFrom.ConfigMapRef.Name] = true
+			}
+			if envFrom.SecretRef != nil {
+				secrets[envFrom.SecretRef.Name] = true
+			}
+		}
+	}
+
+	return getSortedSetKeys(configMaps), getSortedSetKeys(secrets)
+}
+
+// getSortedSetKeys returns the keys of a set, in order
+func getSortedSetKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// getSortedDataKeys returns the keys of the Data of a ConfigMap, in order
+func getSortedDataKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// getSortedBinaryDataKeys returns the keys of the Data of a Secret or of the BinaryData of a ConfigMap, in order
+func getSortedBinaryDataKeys(m map[string][]byte) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -4,7 +4,8 @@ import (
 	"context"
 	"fmt"
 
-	apiv1alpha1 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha1"
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -13,10 +14,10 @@ import (
 )
 
 // EnsureHousekeepingKey makes sure that a valid Housekeeping key is available
-func EnsureHousekeepingKey(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Scheme) error {
+func EnsureHousekeepingKey(ctx context.Context, cr *apiv1alpha2.Astarte, c client.Client, scheme *runtime.Scheme) error {
 	publicSecretName := fmt.Sprintf("%s-housekeeping-public-key", cr.Name)
 	theSecret := &v1.Secret{}
-	err := c.Get(context.TODO(), types.NamespacedName{Name: publicSecretName, Namespace: cr.Namespace}, theSecret)
+	err := c.Get(ctx, types.NamespacedName{Name: publicSecretName, Namespace: cr.Namespace}, theSecret)
 	if err != nil && !errors.IsNotFound(err) {
 		return err
 	} else if errors.IsNotFound(err) {
@@ -24,11 +25,11 @@ func EnsureHousekeepingKey(cr *apiv1alpha1.Astarte, c client.Client, scheme *run
 		privateSecretName := fmt.Sprintf("%s-housekeeping-private-key", cr.Name)
 		reqLogger := log.WithValues("Request.Namespace", cr.Namespace, "Request.Name", cr.Name)
 		// Check if a private key already exists - in that case, we want to erase it.
-		err := c.Get(context.TODO(), types.NamespacedName{Name: privateSecretName, Namespace: cr.Namespace}, theSecret)
+		err := c.Get(ctx, types.NamespacedName{Name: privateSecretName, Namespace: cr.Namespace}, theSecret)
 		if err == nil {
 			// If the call had no errors, it means the private key exists.
 			reqLogger.Info("Existing Housekeeping Private Key found with no matching public key: deleting the existing private key")
-			if err = c.Delete(context.TODO(), theSecret); err != nil {
+			if err = c.Delete(ctx, theSecret); err != nil {
 				reqLogger.Error(err, "Could not delete the previous Housekeeping Private key!")
 				return err
 			}
@@ -44,14 +45,19 @@ func EnsureHousekeepingKey(cr *apiv1alpha1.Astarte, c client.Client, scheme *run
 		}
 
 		reqLogger.Info("Creating Housekeeping private Key Secret")
-		if err = storePrivateKeyInSecret(privateSecretName, key, cr, c, scheme); err != nil {
+		if err = storePrivateKeyInSecret(ctx, privateSecretName, key, cr, c, scheme); err != nil {
 			return err
 		}
 
 		reqLogger.Info("Creating Housekeeping public Key Secret")
-		if err = storePublicKeyInSecret(publicSecretName, &key.PublicKey, cr, c, scheme); err != nil {
+		if err = storePublicKeyInSecret(ctx, publicSecretName, &key.PublicKey, cr, c, scheme); err != nil {
 			return err
 		}
+		// Planned Secrets aren't actually stored, so the key pair deserves no Event
+		if !misc.IsPlanning(cr) {
+			misc.RecordEvent(ctx, cr, misc.EventReasonHousekeepingKeyGenerated, "Generated a new Housekeeping key pair, stored in Secrets %s and %s",
+				privateSecretName, publicSecretName)
+		}
 	}
 
 	// All good.
@@ -59,7 +65,7 @@ func EnsureHousekeepingKey(cr *apiv1alpha1.Astarte, c client.Client, scheme *run
 }
 
 // EnsureGenericErlangConfiguration reconciles the generic Erlang Configuration for Astarte services
-func EnsureGenericErlangConfiguration(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Scheme) error {
+func EnsureGenericErlangConfiguration(ctx context.Context, cr *apiv1alpha2.Astarte, c client.Client, scheme *runtime.Scheme) error {
 	genericErlangConfigurationMapName := fmt.Sprintf("%s-generic-erlang-configuration", cr.Name)
 
 	genericErlangConfigurationMapData := map[string]string{
@@ -88,6 +94,6 @@ func EnsureGenericErlangConfiguration(cr *apiv1alpha1.Astarte, c client.Client,
 `,
 	}
 
-	_, err := reconcileConfigMap(genericErlangConfigurationMapName, genericErlangConfigurationMapData, cr, c, scheme)
+	_, err := reconcileConfigMap(ctx, genericErlangConfigurationMapName, "", genericErlangConfigurationMapData, cr, c, scheme)
 	return err
 }
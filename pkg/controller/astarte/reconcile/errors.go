@@ -0,0 +1,138 @@
+package reconcile
+
+import (
+	"errors"
+	"time"
+
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// ErrorClass tells how a reconciliation error should be handled: whether it makes the Astarte Resource Failed, and
+// when it makes sense to try again
+type ErrorClass string
+
+const (
+	// ErrorClassTransient is used for errors which are expected to go away on their own, such as API Server
+	// failures or conflicts. Errors which were not classified are considered transient.
+	ErrorClassTransient ErrorClass = "Transient"
+	// ErrorClassDependencyNotReady is used when something Astarte relies upon, but the Operator doesn't manage,
+	// is not there yet
+	ErrorClassDependencyNotReady ErrorClass = "DependencyNotReady"
+	// ErrorClassUpgradeBlocked is used when an upgrade can't proceed until the cluster or its status is fixed
+	ErrorClassUpgradeBlocked ErrorClass = "UpgradeBlocked"
+	// ErrorClassInvalidSpec is used when the Spec can't be reconciled as it is, and has to be changed by the user
+	ErrorClassInvalidSpec ErrorClass = "InvalidSpec"
+)
+
+// Classes which are not transient hold the reconciliation until something outside the Operator changes. Changes to
+// the Astarte Resource or to the objects it owns trigger a reconciliation anyway, so these are just a safety net.
+var errorClassRequeueAfter = map[ErrorClass]time.Duration{
+	ErrorClassDependencyNotReady: 30 * time.Second,
+	ErrorClassUpgradeBlocked:     time.Minute,
+	ErrorClassInvalidSpec:        5 * time.Minute,
+}
+
+// Classes by decreasing severity. When several errors happen in the same reconciliation, the most severe one
+// determines how the Astarte Resource is handled.
+var errorClassesBySeverity = []ErrorClass{
+	ErrorClassInvalidSpec,
+	ErrorClassUpgradeBlocked,
+	ErrorClassDependencyNotReady,
+	ErrorClassTransient,
+}
+
+// RequeueAfter returns how long to wait before reconciling again after an error of this class. Transient errors
+// return 0, as they are retried with the exponential backoff of the Controller's rate limiter.
+func (c ErrorClass) RequeueAfter() time.Duration {
+	return errorClassRequeueAfter[c]
+}
+
+// IsFailure returns whether errors of this class put the Astarte Resource in the Failed phase, as they won't go away
+// without a manual intervention
+func (c ErrorClass) IsFailure() bool {
+	return c == ErrorClassInvalidSpec || c == ErrorClassUpgradeBlocked
+}
+
+// ReconcileError is an error which happened while reconciling an Astarte Resource, together with its class and the
+// Reason reported in its Conditions
+type ReconcileError struct {
+	Class  ErrorClass
+	Reason string
+	Err    error
+}
+
+func (e *ReconcileError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the error which caused e
+func (e *ReconcileError) Unwrap() error {
+	return e.Err
+}
+
+// NewTransientError marks err as transient
+func NewTransientError(err error) error {
+	return &ReconcileError{Class: ErrorClassTransient, Reason: apiv1alpha2.ReasonTransientError, Err: err}
+}
+
+// NewDependencyNotReadyError marks err as caused by a dependency which is not there yet
+func NewDependencyNotReadyError(err error) error {
+	return &ReconcileError{Class: ErrorClassDependencyNotReady, Reason: apiv1alpha2.ReasonDependencyNotReady, Err: err}
+}
+
+// NewUpgradeBlockedError marks err as blocking an upgrade
+func NewUpgradeBlockedError(err error) error {
+	return &ReconcileError{Class: ErrorClassUpgradeBlocked, Reason: apiv1alpha2.ReasonUpgradeBlocked, Err: err}
+}
+
+// NewInvalidSpecError marks err as caused by an invalid Spec
+func NewInvalidSpecError(err error) error {
+	return &ReconcileError{Class: ErrorClassInvalidSpec, Reason: apiv1alpha2.ReasonInvalidSpec, Err: err}
+}
+
+// NewUnsupportedVersionError marks err as caused by an Astarte version this Operator can't handle
+func NewUnsupportedVersionError(err error) error {
+	return &ReconcileError{Class: ErrorClassInvalidSpec, Reason: apiv1alpha2.ReasonUnsupportedVersion, Err: err}
+}
+
+// ClassifyError returns err as a ReconcileError. Aggregates are classified as the most severe of their errors.
+// Objects rejected by the API Server as invalid were rendered from the Spec, which is therefore invalid. Any other
+// error is transient.
+func ClassifyError(err error) *ReconcileError {
+	if aggregate, ok := err.(utilerrors.Aggregate); ok {
+		var mostSevere *ReconcileError
+		for _, e := range aggregate.Errors() {
+			classified := ClassifyError(e)
+			if mostSevere == nil || getErrorClassSeverity(classified.Class) < getErrorClassSeverity(mostSevere.Class) {
+				mostSevere = classified
+			}
+		}
+		if mostSevere != nil {
+			// The aggregate is kept as the error, so that no failure goes unreported
+			return &ReconcileError{Class: mostSevere.Class, Reason: mostSevere.Reason, Err: err}
+		}
+	}
+
+	var reconcileErr *ReconcileError
+	if errors.As(err, &reconcileErr) {
+		return &ReconcileError{Class: reconcileErr.Class, Reason: reconcileErr.Reason, Err: err}
+	}
+	// kerrors.IsInvalid doesn't unwrap, and Component errors are wrapped with the name of their step
+	var apiStatus kerrors.APIStatus
+	if errors.As(err, &apiStatus) && apiStatus.Status().Reason == metav1.StatusReasonInvalid {
+		return &ReconcileError{Class: ErrorClassInvalidSpec, Reason: apiv1alpha2.ReasonInvalidSpec, Err: err}
+	}
+	return &ReconcileError{Class: ErrorClassTransient, Reason: apiv1alpha2.ReasonTransientError, Err: err}
+}
+
+func getErrorClassSeverity(class ErrorClass) int {
+	for i, c := range errorClassesBySeverity {
+		if c == class {
+			return i
+		}
+	}
+	return len(errorClassesBySeverity)
+}
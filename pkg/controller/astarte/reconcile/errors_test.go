@@ -0,0 +1,48 @@
+package reconcile
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestClassifyError(t *testing.T) {
+	invalid := kerrors.NewInvalid(schema.GroupKind{Group: "apps", Kind: "Deployment"}, "example-astarte-housekeeping",
+		field.ErrorList{field.Invalid(field.NewPath("spec", "replicas"), -1, "must be greater than or equal to 0")})
+	conflict := kerrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, "example-astarte-housekeeping",
+		errors.New("the object has been modified"))
+
+	testCases := []struct {
+		name  string
+		err   error
+		class ErrorClass
+	}{
+		{"unclassified", errors.New("boom"), ErrorClassTransient},
+		{"conflict", conflict, ErrorClassTransient},
+		{"invalid", invalid, ErrorClassInvalidSpec},
+		{"wrapped invalid", fmt.Errorf("housekeeping: %w", invalid), ErrorClassInvalidSpec},
+		{"wrapped conflict", fmt.Errorf("housekeeping: %w", conflict), ErrorClassTransient},
+		{"wrapped classified", fmt.Errorf("cfssl: %w", NewDependencyNotReadyError(errors.New("no CA"))), ErrorClassDependencyNotReady},
+		{"aggregate", utilerrors.NewAggregate([]error{
+			fmt.Errorf("pairing: %w", conflict),
+			fmt.Errorf("housekeeping: %w", invalid),
+		}), ErrorClassInvalidSpec},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			classified := ClassifyError(tc.err)
+			if classified.Class != tc.class {
+				t.Errorf("expected class %s, got %s", tc.class, classified.Class)
+			}
+			if classified.Error() != tc.err.Error() {
+				t.Errorf("expected the original error to be kept, got %v", classified.Err)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package reconcile
+
+import (
+	"context"
+
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
+	"github.com/openlyinc/pointy"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// ensureHorizontalPodAutoscaler reconciles the HorizontalPodAutoscaler of a Component's Deployment, named after it.
+// The autoscaler is deleted when the Component is not deployed, or when autoscaling is disabled.
+func ensureHorizontalPodAutoscaler(ctx context.Context, deploymentName string, labels map[string]string, autoscaling *apiv1alpha2.AstarteAutoscalingSpec,
+	deployed bool, cr *apiv1alpha2.Astarte, c client.Client, scheme *runtime.Scheme) error {
+	if !deployed || !isAutoscalingEnabled(autoscaling) {
+		theHPA := &autoscalingv2beta2.HorizontalPodAutoscaler{}
+		err := c.Get(ctx, types.NamespacedName{Name: deploymentName, Namespace: cr.Namespace}, theHPA)
+		if err == nil {
+			log.Info("Deleting previously existing HorizontalPodAutoscaler, which is no longer needed", "HorizontalPodAutoscaler.Name", deploymentName)
+			if err := misc.DeleteOwnedResource(ctx, cr, theHPA, c); err != nil {
+				return err
+			}
+			return nil
+		} else if !errors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Name: deploymentName, Namespace: cr.Namespace}}
+	result, err := misc.Apply(ctx, c, scheme, hpa, func() error {
+		if err := controllerutil.SetControllerReference(cr, hpa, scheme); err != nil {
+			return err
+		}
+		hpa.ObjectMeta.Labels = labels
+		hpa.Spec = autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       deploymentName,
+			},
+			MinReplicas: autoscaling.MinReplicas,
+			MaxReplicas: autoscaling.MaxReplicas,
+			Metrics:     getHorizontalPodAutoscalerMetrics(autoscaling),
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logCreateOrUpdateOperationResult(ctx, result, cr, hpa)
+	return nil
+}
+
+func getHorizontalPodAutoscalerMetrics(autoscaling *apiv1alpha2.AstarteAutoscalingSpec) []autoscalingv2beta2.MetricSpec {
+	metrics := []autoscalingv2beta2.MetricSpec{}
+	targets := []struct {
+		resource v1.ResourceName
+		target   *int32
+	}{
+		{v1.ResourceCPU, autoscaling.TargetCPUUtilizationPercentage},
+		{v1.ResourceMemory, autoscaling.TargetMemoryUtilizationPercentage},
+	}
+
+	for _, t := range targets {
+		if t.target == nil {
+			continue
+		}
+		metrics = append(metrics, autoscalingv2beta2.MetricSpec{
+			Type: autoscalingv2beta2.ResourceMetricSourceType,
+			Resource: &autoscalingv2beta2.ResourceMetricSource{
+				Name: t.resource,
+				Target: autoscalingv2beta2.MetricTarget{
+					Type:               autoscalingv2beta2.UtilizationMetricType,
+					AverageUtilization: t.target,
+				},
+			},
+		})
+	}
+
+	return metrics
+}
+
+func isAutoscalingEnabled(autoscaling *apiv1alpha2.AstarteAutoscalingSpec) bool {
+	return autoscaling != nil && pointy.BoolValue(autoscaling.Enabled, false)
+}
+
+// getReplicasForDeployment returns the replicas a Component's Deployment should be set to. When autoscaling is
+// enabled, replicas are owned by the HorizontalPodAutoscaler and are left out of the applied Deployment: new
+// Deployments start from the default single replica, and are scaled up to the minimum by the Autoscaler.
+func getReplicasForDeployment(resource apiv1alpha2.AstarteGenericClusteredResource, autoscaling *apiv1alpha2.AstarteAutoscalingSpec) *int32 {
+	if isAutoscalingEnabled(autoscaling) {
+		return nil
+	}
+	return resource.Replicas
+}
+
+// getClusteredResourceForDisruptionBudget returns resource with its replicas set to the least the Component can be
+// scaled to, so that autoscaled Components get a Disruption Budget as long as they run more than one replica.
+func getClusteredResourceForDisruptionBudget(resource apiv1alpha2.AstarteGenericClusteredResource,
+	autoscaling *apiv1alpha2.AstarteAutoscalingSpec) apiv1alpha2.AstarteGenericClusteredResource {
+	if isAutoscalingEnabled(autoscaling) {
+		resource.Replicas = pointy.Int32(pointy.Int32Value(autoscaling.MinReplicas, 1))
+	}
+	return resource
+}
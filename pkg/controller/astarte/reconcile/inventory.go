@@ -0,0 +1,111 @@
+package reconcile
+
+import (
+	"context"
+
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Objects created for a Component carry these labels, which make up the Component's inventory: when the Component
+// is no longer deployed, everything in its inventory is pruned.
+const (
+	inventoryInstanceLabel  = "api.astarte-platform.org/astarte"
+	inventoryComponentLabel = "api.astarte-platform.org/component"
+)
+
+// newInventoryLists returns empty lists of all kinds which can be part of a Component's inventory
+func newInventoryLists() []runtime.Object {
+	return []runtime.Object{
+		&appsv1.DeploymentList{},
+		&appsv1.StatefulSetList{},
+		&v1.ServiceList{},
+		&v1.ConfigMapList{},
+		&v1.SecretList{},
+		&v1.ServiceAccountList{},
+		&rbacv1.RoleList{},
+		&rbacv1.RoleBindingList{},
+	}
+}
+
+func getInventoryLabels(cr *apiv1alpha2.Astarte, component string) map[string]string {
+	return map[string]string{
+		inventoryInstanceLabel:  cr.Name,
+		inventoryComponentLabel: component,
+	}
+}
+
+// setInventoryLabels adds obj to the inventory of component. Objects shared by the whole Astarte Resource have no
+// Component, and are never pruned.
+func setInventoryLabels(obj metav1.Object, cr *apiv1alpha2.Astarte, component string) {
+	if component == "" {
+		return
+	}
+
+	// Labels are copied, as the current map is often shared with selectors
+	labels := map[string]string{}
+	for k, v := range obj.GetLabels() {
+		labels[k] = v
+	}
+	for k, v := range getInventoryLabels(cr, component) {
+		labels[k] = v
+	}
+	obj.SetLabels(labels)
+}
+
+// applyComponentObject works like misc.Apply, and adds obj to the inventory of component
+func applyComponentObject(ctx context.Context, component string, cr *apiv1alpha2.Astarte, c client.Client, scheme *runtime.Scheme, obj runtime.Object,
+	f controllerutil.MutateFn) (controllerutil.OperationResult, error) {
+	return misc.Apply(ctx, c, scheme, obj, func() error {
+		if err := f(); err != nil {
+			return err
+		}
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return err
+		}
+		setInventoryLabels(accessor, cr, component)
+		return nil
+	})
+}
+
+// pruneComponentInventory deletes all objects in the inventory of a Component which is no longer deployed. Only
+// objects controlled by the Astarte Resource are considered.
+func pruneComponentInventory(ctx context.Context, component string, cr *apiv1alpha2.Astarte, c client.Client) error {
+	reqLogger := log.WithValues("Request.Namespace", cr.Namespace, "Request.Name", cr.Name, "Astarte.Component", component)
+	for _, list := range newInventoryLists() {
+		if err := c.List(ctx, list, client.InNamespace(cr.Namespace),
+			client.MatchingLabels(getInventoryLabels(cr, component))); err != nil {
+			return err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			accessor, err := meta.Accessor(item)
+			if err != nil {
+				return err
+			}
+			if !metav1.IsControlledBy(accessor, cr) {
+				continue
+			}
+			reqLogger.Info("Pruning object of a Component which is no longer deployed", "Resource", accessor.GetName())
+			if err := misc.DeleteOwnedResource(ctx, cr, item, c); err != nil && !kerrors.IsNotFound(err) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package reconcile
+
+import (
+	"context"
+
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
+	"github.com/openlyinc/pointy"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// ensurePodDisruptionBudget reconciles the PodDisruptionBudget of a clustered Component, named after it. The budget is
+// deleted when the Component is not deployed, or when it doesn't need one.
+func ensurePodDisruptionBudget(ctx context.Context, name string, matchLabels map[string]string, resource apiv1alpha2.AstarteGenericClusteredResource,
+	cr *apiv1alpha2.Astarte, c client.Client, scheme *runtime.Scheme) error {
+	pdbSpec, needed := getPodDisruptionBudgetSpec(matchLabels, resource)
+	if !pointy.BoolValue(resource.Deploy, true) || !needed {
+		thePDB := &policyv1beta1.PodDisruptionBudget{}
+		err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: cr.Namespace}, thePDB)
+		if err == nil {
+			log.Info("Deleting previously existing PodDisruptionBudget, which is no longer needed", "PodDisruptionBudget.Name", name)
+			if err := misc.DeleteOwnedResource(ctx, cr, thePDB, c); err != nil {
+				return err
+			}
+			return nil
+		} else if !errors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	pdb := &policyv1beta1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: cr.Namespace}}
+	result, err := misc.Apply(ctx, c, scheme, pdb, func() error {
+		if err := controllerutil.SetControllerReference(cr, pdb, scheme); err != nil {
+			return err
+		}
+		pdb.ObjectMeta.Labels = matchLabels
+		pdb.Spec = pdbSpec
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logCreateOrUpdateOperationResult(ctx, result, cr, pdb)
+	return nil
+}
+
+// getPodDisruptionBudgetSpec returns the Disruption Budget of a Component, and whether it needs one at all.
+// Unless specified otherwise, Components with a single replica have no budget, as it would prevent Nodes from
+// being drained, while all others can have one replica disrupted at a time.
+func getPodDisruptionBudgetSpec(matchLabels map[string]string, resource apiv1alpha2.AstarteGenericClusteredResource) (policyv1beta1.PodDisruptionBudgetSpec, bool) {
+	pdbSpec := policyv1beta1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: matchLabels}}
+
+	if resource.PodDisruptionBudget != nil {
+		if !pointy.BoolValue(resource.PodDisruptionBudget.Enabled, true) {
+			return pdbSpec, false
+		}
+		if resource.PodDisruptionBudget.MinAvailable != nil || resource.PodDisruptionBudget.MaxUnavailable != nil {
+			pdbSpec.MinAvailable = resource.PodDisruptionBudget.MinAvailable
+			pdbSpec.MaxUnavailable = resource.PodDisruptionBudget.MaxUnavailable
+			return pdbSpec, true
+		}
+	}
+
+	if pointy.Int32Value(resource.Replicas, 1) <= 1 {
+		return pdbSpec, false
+	}
+	maxUnavailable := intstr.FromInt(1)
+	pdbSpec.MaxUnavailable = &maxUnavailable
+	return pdbSpec, true
+}
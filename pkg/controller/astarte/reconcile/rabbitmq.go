@@ -12,13 +12,14 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	semver "github.com/Masterminds/semver/v3"
-	apiv1alpha1 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha1"
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
 	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/controller/astarte/deps"
 	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
 	"github.com/openlyinc/pointy"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -27,7 +28,7 @@ import (
 )
 
 // EnsureRabbitMQ reconciles the state of RabbitMQ
-func EnsureRabbitMQ(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Scheme) error {
+func EnsureRabbitMQ(ctx context.Context, cr *apiv1alpha2.Astarte, c client.Client, scheme *runtime.Scheme) error {
 	statefulSetName := cr.Name + "-rabbitmq"
 	labels := map[string]string{"app": statefulSetName}
 
@@ -35,6 +36,9 @@ func EnsureRabbitMQ(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Sc
 	if err := validateRabbitMQDefinition(cr.Spec.RabbitMQ); err != nil {
 		return err
 	}
+	if err := checkRabbitMQConnectionSecret(ctx, cr, c); err != nil {
+		return err
+	}
 
 	// Depending on the situation, we need to take action on the credentials.
 	createUserCredentialsSecret := true
@@ -49,76 +53,92 @@ func EnsureRabbitMQ(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Sc
 
 	if createUserCredentialsSecret {
 		userCredentialsSecret := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: statefulSetName + "-user-credentials", Namespace: cr.Namespace}}
-		if result, err := controllerutil.CreateOrUpdate(context.TODO(), c, userCredentialsSecret, func() error {
+		// Generated credentials must survive reconciliations: look them up before applying the Secret
+		existingSecret := &v1.Secret{}
+		if err := c.Get(ctx, types.NamespacedName{Name: userCredentialsSecret.Name, Namespace: cr.Namespace}, existingSecret); err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+		// The credentials are used to connect to RabbitMQ even when it's not deployed, and are not part of its inventory
+		if result, err := misc.Apply(ctx, c, scheme, userCredentialsSecret, func() error {
 			if err := controllerutil.SetControllerReference(cr, userCredentialsSecret, scheme); err != nil {
 				return err
 			}
 			if createUserCredentialsSecretFromCredentials {
 				// Ensure the Data field matches
-				userCredentialsSecret.StringData[misc.RabbitMQDefaultUserCredentialsUsernameKey] = cr.Spec.RabbitMQ.Connection.Username
-				userCredentialsSecret.StringData[misc.RabbitMQDefaultUserCredentialsPasswordKey] = cr.Spec.RabbitMQ.Connection.Password
+				userCredentialsSecret.Data = map[string][]byte{
+					misc.RabbitMQDefaultUserCredentialsUsernameKey: []byte(cr.Spec.RabbitMQ.Connection.Username),
+					misc.RabbitMQDefaultUserCredentialsPasswordKey: []byte(cr.Spec.RabbitMQ.Connection.Password),
+				}
+			} else if _, ok := existingSecret.Data[misc.RabbitMQDefaultUserCredentialsUsernameKey]; ok {
+				// Keep the credentials we generated already
+				userCredentialsSecret.Data = existingSecret.Data
 			} else {
-				// In this case, see if we need to generate new secrets. Otherwise just skip.
-				if _, ok := userCredentialsSecret.Data[misc.RabbitMQDefaultUserCredentialsUsernameKey]; !ok {
-					// Create a new, random password out of 16 bytes of entropy
-					password := make([]byte, 16)
-					rand.Read(password)
-					userCredentialsSecret.StringData = map[string]string{
-						misc.RabbitMQDefaultUserCredentialsUsernameKey: "astarte-admin",
-						misc.RabbitMQDefaultUserCredentialsPasswordKey: base64.URLEncoding.EncodeToString(password),
-					}
+				// Create a new, random password out of 16 bytes of entropy
+				password := make([]byte, 16)
+				rand.Read(password)
+				userCredentialsSecret.Data = map[string][]byte{
+					misc.RabbitMQDefaultUserCredentialsUsernameKey: []byte("astarte-admin"),
+					misc.RabbitMQDefaultUserCredentialsPasswordKey: []byte(base64.URLEncoding.EncodeToString(password)),
 				}
 			}
 			return nil
 		}); err == nil {
-			logCreateOrUpdateOperationResult(result, cr, userCredentialsSecret)
+			logCreateOrUpdateOperationResult(ctx, result, cr, userCredentialsSecret)
 		} else {
 			return err
 		}
 	} else {
 		// Maybe delete it, if we created it already?
 		theSecret := &v1.Secret{}
-		if err := c.Get(context.TODO(), types.NamespacedName{Name: statefulSetName + "-user-credentials", Namespace: cr.Namespace}, theSecret); err == nil {
-			if err := c.Delete(context.TODO(), theSecret); err != nil {
+		if err := c.Get(ctx, types.NamespacedName{Name: statefulSetName + "-user-credentials", Namespace: cr.Namespace}, theSecret); err == nil {
+			if err := misc.DeleteOwnedResource(ctx, cr, theSecret, c); err != nil {
 				return err
 			}
 		}
 	}
 
+	// The Disruption Budget follows the replicas of the Component, and goes away together with it
+	if err := ensurePodDisruptionBudget(ctx, statefulSetName, labels, cr.Spec.RabbitMQ.AstarteGenericClusteredResource, cr, c, scheme); err != nil {
+		return err
+	}
+
 	// Ok. Shall we deploy?
-	if !pointy.BoolValue(cr.Spec.RabbitMQ.GenericClusteredResource.Deploy, true) {
+	if !pointy.BoolValue(cr.Spec.RabbitMQ.Deploy, true) {
 		log.Info("Skipping RabbitMQ Deployment")
-		// Before returning - check if we shall clean up the StatefulSet.
-		// It is the only thing actually requiring resources, the rest will be cleaned up eventually when the
-		// Astarte resource is deleted.
+		// Before returning - clean up everything in the Component's inventory. The StatefulSet is looked up by
+		// name too, as it might predate the inventory.
 		theStatefulSet := &appsv1.StatefulSet{}
-		err := c.Get(context.TODO(), types.NamespacedName{Name: statefulSetName, Namespace: cr.Namespace}, theStatefulSet)
+		err := c.Get(ctx, types.NamespacedName{Name: statefulSetName, Namespace: cr.Namespace}, theStatefulSet)
 		if err == nil {
 			log.Info("Deleting previously existing RabbitMQ StatefulSet, which is no longer needed")
-			if err = c.Delete(context.TODO(), theStatefulSet); err != nil {
+			if err := misc.DeleteOwnedResource(ctx, cr, theStatefulSet, c); err != nil {
 				return err
 			}
 		}
 
+		if err := pruneComponentInventory(ctx, "rabbitmq", cr, c); err != nil {
+			return err
+		}
+
 		// That would be all for today.
 		return nil
 	}
 
 	// First of all, check if we need to regenerate the cookie.
-	if err := ensureErlangCookieSecret(statefulSetName+"-cookie", cr, c, scheme); err != nil {
+	if err := ensureErlangCookieSecret(ctx, statefulSetName+"-cookie", "rabbitmq", cr, c, scheme); err != nil {
 		return err
 	}
 
 	// Ensure we reconcile with the RBAC Roles, if needed.
 	if pointy.BoolValue(cr.Spec.RBAC, true) {
-		if err := reconcileStandardRBACForClusteringForApp(statefulSetName, getRabbitMQPolicyRules(), cr, c, scheme); err != nil {
+		if err := reconcileStandardRBACForClusteringForApp(ctx, statefulSetName, "rabbitmq", getRabbitMQPolicyRules(), cr, c, scheme); err != nil {
 			return err
 		}
 	}
 
 	// Good. Now, reconcile the service first of all.
 	service := &v1.Service{ObjectMeta: getCommonRabbitMQObjectMeta(statefulSetName, cr)}
-	if result, err := controllerutil.CreateOrUpdate(context.TODO(), c, service, func() error {
+	if result, err := applyComponentObject(ctx, "rabbitmq", cr, c, scheme, service, func() error {
 		if err := controllerutil.SetControllerReference(cr, service, scheme); err != nil {
 			return err
 		}
@@ -142,13 +162,13 @@ func EnsureRabbitMQ(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Sc
 		service.Spec.Selector = labels
 		return nil
 	}); err == nil {
-		logCreateOrUpdateOperationResult(result, cr, service)
+		logCreateOrUpdateOperationResult(ctx, result, cr, service)
 	} else {
 		return err
 	}
 
 	// Good. Reconcile the ConfigMap.
-	if _, err := reconcileConfigMap(statefulSetName+"-config", getRabbitMQConfigMapData(statefulSetName, cr), cr, c, scheme); err != nil {
+	if _, err := reconcileConfigMap(ctx, statefulSetName+"-config", "rabbitmq", getRabbitMQConfigMapData(statefulSetName, cr), cr, c, scheme); err != nil {
 		return err
 	}
 
@@ -163,27 +183,30 @@ func EnsureRabbitMQ(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Sc
 			MatchLabels: labels,
 		},
 		Template: v1.PodTemplateSpec{
-			ObjectMeta: metav1.ObjectMeta{
-				Labels: labels,
-			},
-			Spec: getRabbitMQPodSpec(statefulSetName, dataVolumeName, cr),
+			ObjectMeta: getPodTemplateObjectMeta(labels, cr.Spec.RabbitMQ.AstartePodSchedulingSpec, cr.Spec.RabbitMQ.AstartePodSecuritySpec),
+			Spec:       getRabbitMQPodSpec(statefulSetName, dataVolumeName, cr),
 		},
 	}
 
+	// Roll the Pods whenever the configuration or credentials they consume change
+	if err := setConfigurationChecksums(ctx, &statefulSetSpec.Template, cr, c); err != nil {
+		return err
+	}
+
 	if persistentVolumeClaim != nil {
 		statefulSetSpec.VolumeClaimTemplates = []v1.PersistentVolumeClaim{*persistentVolumeClaim}
 	}
 
 	// Build the StatefulSet
 	rmqStatefulSet := &appsv1.StatefulSet{ObjectMeta: getCommonRabbitMQObjectMeta(statefulSetName, cr)}
-	result, err := controllerutil.CreateOrUpdate(context.TODO(), c, rmqStatefulSet, func() error {
+	result, err := applyComponentObject(ctx, "rabbitmq", cr, c, scheme, rmqStatefulSet, func() error {
 		if err := controllerutil.SetControllerReference(cr, rmqStatefulSet, scheme); err != nil {
 			return err
 		}
 
 		// Assign the Spec.
 		rmqStatefulSet.Spec = statefulSetSpec
-		rmqStatefulSet.Spec.Replicas = cr.Spec.RabbitMQ.GenericClusteredResource.Replicas
+		rmqStatefulSet.Spec.Replicas = cr.Spec.RabbitMQ.Replicas
 
 		return nil
 	})
@@ -191,27 +214,44 @@ func EnsureRabbitMQ(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Sc
 		return err
 	}
 
-	logCreateOrUpdateOperationResult(result, cr, service)
+	logCreateOrUpdateOperationResult(ctx, result, cr, service)
 	return nil
 }
 
-func validateRabbitMQDefinition(rmq apiv1alpha1.AstarteRabbitMQSpec) error {
-	if !pointy.BoolValue(rmq.GenericClusteredResource.Deploy, true) {
+func validateRabbitMQDefinition(rmq apiv1alpha2.AstarteRabbitMQSpec) error {
+	if !pointy.BoolValue(rmq.Deploy, true) {
 		// We need to make sure that we have all needed components
 		if rmq.Connection == nil {
-			return errors.New("When not deploying RabbitMQ, the 'connection' section is compulsory")
+			return NewInvalidSpecError(errors.New("When not deploying RabbitMQ, the 'connection' section is compulsory"))
 		}
 		if rmq.Connection.Host == "" {
-			return errors.New("When not deploying RabbitMQ, it is compulsory to specify at least a Host")
+			return NewInvalidSpecError(errors.New("When not deploying RabbitMQ, it is compulsory to specify at least a Host"))
 		}
 		if (rmq.Connection.Username == "" || rmq.Connection.Password == "") && rmq.Connection.Secret == nil {
-			return errors.New("When not deploying RabbitMQ, either a username/password combination or a Kubernetes secret must be provided")
+			return NewInvalidSpecError(errors.New("When not deploying RabbitMQ, either a username/password combination or a Kubernetes secret must be provided"))
 		}
 	}
 	// All is good.
 	return nil
 }
 
+// checkRabbitMQConnectionSecret ensures the Secret holding the credentials of an external RabbitMQ exists, as it's
+// provided by the user. Its creation triggers a new reconciliation.
+func checkRabbitMQConnectionSecret(ctx context.Context, cr *apiv1alpha2.Astarte, c client.Client) error {
+	if cr.Spec.RabbitMQ.Connection == nil || cr.Spec.RabbitMQ.Connection.Secret == nil {
+		return nil
+	}
+
+	secretName := cr.Spec.RabbitMQ.Connection.Secret.Name
+	if err := c.Get(ctx, types.NamespacedName{Name: secretName, Namespace: cr.Namespace}, &v1.Secret{}); err != nil {
+		if kerrors.IsNotFound(err) {
+			return NewDependencyNotReadyError(fmt.Errorf("RabbitMQ connection Secret %s does not exist", secretName))
+		}
+		return err
+	}
+	return nil
+}
+
 func getRabbitMQInitContainers() []v1.Container {
 	return []v1.Container{
 		v1.Container{
@@ -262,7 +302,7 @@ func getRabbitMQReadinessProbe() *v1.Probe {
 	}
 }
 
-func getRabbitMQEnvVars(statefulSetName string, cr *apiv1alpha1.Astarte) []v1.EnvVar {
+func getRabbitMQEnvVars(statefulSetName string, cr *apiv1alpha2.Astarte) []v1.EnvVar {
 	userCredentialsSecretName, userCredentialsSecretUsernameKey, userCredentialsSecretPasswordKey := misc.GetRabbitMQUserCredentialsSecret(cr)
 
 	return []v1.EnvVar{
@@ -306,9 +346,9 @@ func getRabbitMQEnvVars(statefulSetName string, cr *apiv1alpha1.Astarte) []v1.En
 	}
 }
 
-func getRabbitMQPodSpec(statefulSetName, dataVolumeName string, cr *apiv1alpha1.Astarte) v1.PodSpec {
+func getRabbitMQPodSpec(statefulSetName, dataVolumeName string, cr *apiv1alpha2.Astarte) v1.PodSpec {
 	serviceAccountName := statefulSetName
-	if pointy.BoolValue(cr.Spec.RBAC, false) {
+	if !pointy.BoolValue(cr.Spec.RBAC, true) {
 		serviceAccountName = ""
 	}
 	astarteVersion, _ := semver.NewVersion(cr.Spec.Version)
@@ -318,7 +358,7 @@ func getRabbitMQPodSpec(statefulSetName, dataVolumeName string, cr *apiv1alpha1.
 		ServiceAccountName:            serviceAccountName,
 		InitContainers:                getRabbitMQInitContainers(),
 		ImagePullSecrets:              cr.Spec.ImagePullSecrets,
-		Affinity:                      getAffinityForClusteredResource(statefulSetName, cr.Spec.RabbitMQ.GenericClusteredResource),
+		Affinity:                      getAffinityForClusteredResource(statefulSetName, cr.Spec.RabbitMQ.AstarteGenericClusteredResource),
 		Containers: []v1.Container{
 			v1.Container{
 				Name: "rabbitmq",
@@ -333,7 +373,7 @@ func getRabbitMQPodSpec(statefulSetName, dataVolumeName string, cr *apiv1alpha1.
 					},
 				},
 				Image: getImageForClusteredResource("rabbitmq", deps.GetDefaultVersionForRabbitMQ(astarteVersion),
-					cr.Spec.RabbitMQ.GenericClusteredResource),
+					cr.Spec.RabbitMQ.AstarteGenericClusteredResource),
 				ImagePullPolicy: getImagePullPolicy(cr),
 				Ports: []v1.ContainerPort{
 					v1.ContainerPort{Name: "amqp", ContainerPort: 5672},
@@ -341,7 +381,7 @@ func getRabbitMQPodSpec(statefulSetName, dataVolumeName string, cr *apiv1alpha1.
 				},
 				LivenessProbe:  getRabbitMQLivenessProbe(),
 				ReadinessProbe: getRabbitMQReadinessProbe(),
-				Resources:      cr.Spec.RabbitMQ.GenericClusteredResource.Resources,
+				Resources:      cr.Spec.RabbitMQ.Resources,
 				Env:            getRabbitMQEnvVars(statefulSetName, cr),
 			},
 		},
@@ -371,10 +411,14 @@ func getRabbitMQPodSpec(statefulSetName, dataVolumeName string, cr *apiv1alpha1.
 		},
 	}
 
+	applyPodSchedulingSpec(&ps, cr.Spec.RabbitMQ.AstartePodSchedulingSpec)
+	applyPodExtensionsSpec(&ps, cr.Spec.RabbitMQ.AstartePodExtensionsSpec)
+	applyPodSecuritySpec(&ps, cr.Spec.RabbitMQ.AstartePodSecuritySpec)
+
 	return ps
 }
 
-func getRabbitMQConfigMapData(statefulSetName string, cr *apiv1alpha1.Astarte) map[string]string {
+func getRabbitMQConfigMapData(statefulSetName string, cr *apiv1alpha2.Astarte) map[string]string {
 	rmqPlugins := []string{"rabbitmq_management", "rabbitmq_peer_discovery_k8s"}
 	if len(cr.Spec.RabbitMQ.AdditionalPlugins) > 0 {
 		rmqPlugins = append(rmqPlugins, cr.Spec.RabbitMQ.AdditionalPlugins...)
@@ -411,7 +455,7 @@ func getRabbitMQPolicyRules() []rbacv1.PolicyRule {
 	}
 }
 
-func getCommonRabbitMQObjectMeta(statefulSetName string, cr *apiv1alpha1.Astarte) metav1.ObjectMeta {
+func getCommonRabbitMQObjectMeta(statefulSetName string, cr *apiv1alpha2.Astarte) metav1.ObjectMeta {
 	labels := map[string]string{"app": statefulSetName}
 	return metav1.ObjectMeta{
 		Name:      statefulSetName,
@@ -14,7 +14,7 @@ import (
 	"strconv"
 
 	semver "github.com/Masterminds/semver/v3"
-	apiv1alpha1 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha1"
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
 	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
 	"github.com/openlyinc/pointy"
 	v1 "k8s.io/api/core/v1"
@@ -28,6 +28,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+// Astarte's Elixir services run as nobody by default
+const astarteServicesUserID int64 = 65534
+
 func encodePEMBlockToEncodedBytes(block *pem.Block) ([]byte, error) {
 	var keyBuffer bytes.Buffer
 	keyWriter := bufio.NewWriter(&keyBuffer)
@@ -43,7 +46,7 @@ func encodePEMBlockToEncodedBytes(block *pem.Block) ([]byte, error) {
 	return keyEncodedData, nil
 }
 
-func storePublicKeyInSecret(name string, publicKey *rsa.PublicKey, cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Scheme) error {
+func storePublicKeyInSecret(ctx context.Context, name string, publicKey *rsa.PublicKey, cr *apiv1alpha2.Astarte, c client.Client, scheme *runtime.Scheme) error {
 	pkixBytes, err := x509.MarshalPKIXPublicKey(publicKey)
 	if err != nil {
 		return err
@@ -63,11 +66,11 @@ func storePublicKeyInSecret(name string, publicKey *rsa.PublicKey, cr *apiv1alph
 	}
 
 	// Set Astarte instance as the owner and controller
-	_, err = reconcileSecret(name, secretData, cr, c, scheme)
+	_, err = reconcileSecret(ctx, name, secretData, cr, c, scheme)
 	return err
 }
 
-func storePrivateKeyInSecret(name string, privateKey *rsa.PrivateKey, cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Scheme) error {
+func storePrivateKeyInSecret(ctx context.Context, name string, privateKey *rsa.PrivateKey, cr *apiv1alpha2.Astarte, c client.Client, scheme *runtime.Scheme) error {
 	var privateKeyPEM = &pem.Block{
 		Type:  "RSA PRIVATE KEY",
 		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
@@ -83,7 +86,7 @@ func storePrivateKeyInSecret(name string, privateKey *rsa.PrivateKey, cr *apiv1a
 	}
 
 	// Set Astarte instance as the owner and controller
-	_, err = reconcileSecret(name, secretData, cr, c, scheme)
+	_, err = reconcileSecret(ctx, name, secretData, cr, c, scheme)
 	return err
 }
 
@@ -94,53 +97,92 @@ func generateKeyPair() (*rsa.PrivateKey, error) {
 	return rsa.GenerateKey(reader, bitSize)
 }
 
-func getStandardAntiAffinityForAppLabel(app string) *v1.Affinity {
-	return &v1.Affinity{
-		PodAntiAffinity: &v1.PodAntiAffinity{
-			RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{
-				v1.PodAffinityTerm{
-					LabelSelector: &metav1.LabelSelector{
-						MatchExpressions: []metav1.LabelSelectorRequirement{
-							metav1.LabelSelectorRequirement{
-								Key:      "app",
-								Operator: metav1.LabelSelectorOpIn,
-								Values:   []string{app},
-							},
-						},
+// getAntiAffinityForAppLabel returns the Affinity spreading Pods labelled with app according to mode. Preferring
+// zones still spreads Pods across Nodes within the same zone.
+func getAntiAffinityForAppLabel(app string, mode apiv1alpha2.AstarteAntiAffinityMode) *v1.Affinity {
+	term := func(topologyKey string) v1.PodAffinityTerm {
+		return v1.PodAffinityTerm{
+			LabelSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					metav1.LabelSelectorRequirement{
+						Key:      "app",
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{app},
 					},
-					TopologyKey: "kubernetes.io/hostname",
 				},
 			},
-		},
+			TopologyKey: topologyKey,
+		}
 	}
+
+	switch mode {
+	case apiv1alpha2.AntiAffinityPreferredHost:
+		return &v1.Affinity{PodAntiAffinity: &v1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []v1.WeightedPodAffinityTerm{
+				v1.WeightedPodAffinityTerm{Weight: 100, PodAffinityTerm: term(v1.LabelHostname)},
+			},
+		}}
+	case apiv1alpha2.AntiAffinityRequiredHost:
+		return &v1.Affinity{PodAntiAffinity: &v1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{term(v1.LabelHostname)},
+		}}
+	case apiv1alpha2.AntiAffinityPreferredZone:
+		return &v1.Affinity{PodAntiAffinity: &v1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []v1.WeightedPodAffinityTerm{
+				v1.WeightedPodAffinityTerm{Weight: 100, PodAffinityTerm: term(v1.LabelZoneFailureDomain)},
+				v1.WeightedPodAffinityTerm{Weight: 50, PodAffinityTerm: term(v1.LabelHostname)},
+			},
+		}}
+	case apiv1alpha2.AntiAffinityRequiredZone:
+		return &v1.Affinity{PodAntiAffinity: &v1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{term(v1.LabelZoneFailureDomain)},
+		}}
+	}
+
+	return nil
 }
 
-func reconcileConfigMap(objName string, data map[string]string, cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Scheme) (controllerutil.OperationResult, error) {
-	return misc.ReconcileConfigMap(objName, data, cr, c, scheme, log)
+// reconcileConfigMap works like misc.ReconcileConfigMap, and adds the ConfigMap to the inventory of component
+func reconcileConfigMap(ctx context.Context, objName, component string, data map[string]string, cr *apiv1alpha2.Astarte, c client.Client, scheme *runtime.Scheme) (controllerutil.OperationResult, error) {
+	configMap := &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: objName, Namespace: cr.Namespace}}
+	result, err := applyComponentObject(ctx, component, cr, c, scheme, configMap, func() error {
+		if err := controllerutil.SetControllerReference(cr, configMap, scheme); err != nil {
+			return err
+		}
+		// Set the ConfigMap data to the requested map
+		configMap.Data = data
+		return nil
+	})
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	logCreateOrUpdateOperationResult(ctx, result, cr, configMap)
+	return result, nil
 }
 
-func reconcileSecret(objName string, data map[string][]byte, cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Scheme) (controllerutil.OperationResult, error) {
-	return misc.ReconcileSecret(objName, data, cr, c, scheme, log)
+func reconcileSecret(ctx context.Context, objName string, data map[string][]byte, cr *apiv1alpha2.Astarte, c client.Client, scheme *runtime.Scheme) (controllerutil.OperationResult, error) {
+	return misc.ReconcileSecret(ctx, objName, data, cr, c, scheme, log)
 }
 
-func reconcileStandardRBACForClusteringForApp(name string, policyRules []rbacv1.PolicyRule, cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Scheme) error {
+func reconcileStandardRBACForClusteringForApp(ctx context.Context, name, component string, policyRules []rbacv1.PolicyRule, cr *apiv1alpha2.Astarte, c client.Client, scheme *runtime.Scheme) error {
 	// Service Account
 	serviceAccount := &v1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: cr.Namespace}}
-	if result, err := controllerutil.CreateOrUpdate(context.TODO(), c, serviceAccount, func() error {
+	if result, err := applyComponentObject(ctx, component, cr, c, scheme, serviceAccount, func() error {
 		if err := controllerutil.SetControllerReference(cr, serviceAccount, scheme); err != nil {
 			return err
 		}
 		// Actually nothing to do here.
 		return nil
 	}); err == nil {
-		logCreateOrUpdateOperationResult(result, cr, serviceAccount)
+		logCreateOrUpdateOperationResult(ctx, result, cr, serviceAccount)
 	} else {
 		return err
 	}
 
 	// Role
 	role := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: cr.Namespace}}
-	if result, err := controllerutil.CreateOrUpdate(context.TODO(), c, role, func() error {
+	if result, err := applyComponentObject(ctx, component, cr, c, scheme, role, func() error {
 		if err := controllerutil.SetControllerReference(cr, role, scheme); err != nil {
 			return err
 		}
@@ -148,14 +190,14 @@ func reconcileStandardRBACForClusteringForApp(name string, policyRules []rbacv1.
 		role.Rules = policyRules
 		return nil
 	}); err == nil {
-		logCreateOrUpdateOperationResult(result, cr, serviceAccount)
+		logCreateOrUpdateOperationResult(ctx, result, cr, serviceAccount)
 	} else {
 		return err
 	}
 
 	// Role Binding
 	roleBinding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: cr.Namespace}}
-	if result, err := controllerutil.CreateOrUpdate(context.TODO(), c, roleBinding, func() error {
+	if result, err := applyComponentObject(ctx, component, cr, c, scheme, roleBinding, func() error {
 		if err := controllerutil.SetControllerReference(cr, roleBinding, scheme); err != nil {
 			return err
 		}
@@ -173,7 +215,7 @@ func reconcileStandardRBACForClusteringForApp(name string, policyRules []rbacv1.
 		}
 		return nil
 	}); err == nil {
-		logCreateOrUpdateOperationResult(result, cr, serviceAccount)
+		logCreateOrUpdateOperationResult(ctx, result, cr, serviceAccount)
 	} else {
 		return err
 	}
@@ -181,11 +223,11 @@ func reconcileStandardRBACForClusteringForApp(name string, policyRules []rbacv1.
 	return nil
 }
 
-func logCreateOrUpdateOperationResult(result controllerutil.OperationResult, cr *apiv1alpha1.Astarte, obj metav1.Object) {
-	misc.LogCreateOrUpdateOperationResult(log, result, cr, obj)
+func logCreateOrUpdateOperationResult(ctx context.Context, result controllerutil.OperationResult, cr *apiv1alpha2.Astarte, obj metav1.Object) {
+	misc.LogCreateOrUpdateOperationResult(ctx, log, result, cr, obj)
 }
 
-func getAstarteImageFromChannel(name, tag string, cr *apiv1alpha1.Astarte) string {
+func getAstarteImageFromChannel(name, tag string, cr *apiv1alpha2.Astarte) string {
 	distributionChannel := "astarte"
 	if cr.Spec.DistributionChannel != "" {
 		distributionChannel = cr.Spec.DistributionChannel
@@ -194,7 +236,7 @@ func getAstarteImageFromChannel(name, tag string, cr *apiv1alpha1.Astarte) strin
 	return fmt.Sprintf("%s/%s:%s", distributionChannel, name, tag)
 }
 
-func getImagePullPolicy(cr *apiv1alpha1.Astarte) v1.PullPolicy {
+func getImagePullPolicy(cr *apiv1alpha2.Astarte) v1.PullPolicy {
 	if cr.Spec.ImagePullPolicy != nil {
 		return *cr.Spec.ImagePullPolicy
 	}
@@ -202,10 +244,10 @@ func getImagePullPolicy(cr *apiv1alpha1.Astarte) v1.PullPolicy {
 	return v1.PullIfNotPresent
 }
 
-func ensureErlangCookieSecret(secretName string, cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Scheme) error {
+func ensureErlangCookieSecret(ctx context.Context, secretName, component string, cr *apiv1alpha2.Astarte, c client.Client, scheme *runtime.Scheme) error {
 	reqLogger := log.WithValues("Request.Namespace", cr.Namespace, "Request.Name", cr.Name)
 	theCookie := &v1.Secret{}
-	if err := c.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: cr.Namespace}, theCookie); err != nil {
+	if err := c.Get(ctx, types.NamespacedName{Name: secretName, Namespace: cr.Namespace}, theCookie); err != nil {
 		if kerrors.IsNotFound(err) {
 			// Create it.
 			// TODO: Throw a reconcile error and/or delete the persistent volume if we are in that situation.
@@ -220,25 +262,32 @@ func ensureErlangCookieSecret(secretName string, cr *apiv1alpha1.Astarte, c clie
 				},
 				StringData: map[string]string{"erlang-cookie": base32.StdEncoding.EncodeToString(cookie)},
 			}
+			setInventoryLabels(&cookieSecret, cr, component)
 			if err := controllerutil.SetControllerReference(cr, &cookieSecret, scheme); err != nil {
 				return err
 			}
 			// We force creation as for no reason in the world we want to even think about updating this.
-			if err = c.Create(context.TODO(), &cookieSecret); err != nil {
+			if err = c.Create(ctx, &cookieSecret); err != nil {
 				return err
 			}
 		} else {
 			// Return here
 			return err
 		}
+	} else if component != "" && theCookie.GetLabels()[inventoryComponentLabel] != component && !misc.IsUnmanaged(theCookie) {
+		// Cookies predating the inventory are added to it, leaving their content untouched
+		setInventoryLabels(theCookie, cr, component)
+		if err := c.Update(ctx, theCookie); err != nil {
+			return err
+		}
 	}
 
 	// All went well
 	return nil
 }
 
-func computePersistentVolumeClaim(defaultName string, defaultSize *resource.Quantity, storageSpec *apiv1alpha1.AstartePersistentStorageSpec,
-	cr *apiv1alpha1.Astarte) (string, *v1.PersistentVolumeClaim) {
+func computePersistentVolumeClaim(defaultName string, defaultSize *resource.Quantity, storageSpec *apiv1alpha2.AstartePersistentStorageSpec,
+	cr *apiv1alpha2.Astarte) (string, *v1.PersistentVolumeClaim) {
 	var storageClassName string
 	dataVolumeSize := defaultSize
 	dataVolumeName := defaultName
@@ -272,7 +321,7 @@ func computePersistentVolumeClaim(defaultName string, defaultSize *resource.Quan
 	}
 }
 
-func getAstarteCommonEnvVars(deploymentName string, cr *apiv1alpha1.Astarte, component apiv1alpha1.AstarteComponent) []v1.EnvVar {
+func getAstarteCommonEnvVars(deploymentName string, cr *apiv1alpha2.Astarte, component apiv1alpha2.AstarteComponent, security apiv1alpha2.AstartePodSecuritySpec) []v1.EnvVar {
 	rabbitMQHost, rabbitMQPort := misc.GetRabbitMQHostnameAndPort(cr)
 	userCredentialsSecretName, userCredentialsSecretUsernameKey, userCredentialsSecretPasswordKey := misc.GetRabbitMQUserCredentialsSecret(cr)
 	ret := []v1.EnvVar{
@@ -322,11 +371,18 @@ func getAstarteCommonEnvVars(deploymentName string, cr *apiv1alpha1.Astarte, com
 			}},
 		},
 	}
+	// Releases write their runtime configuration in the mutable dir, which hardened Pods can't do in their image
+	if isHardened(security) {
+		ret = append(ret, v1.EnvVar{
+			Name:  "RELEASE_MUTABLE_DIR",
+			Value: "/beammutable",
+		})
+	}
 
 	return ret
 }
 
-func getAstarteCommonVolumes(cr *apiv1alpha1.Astarte) []v1.Volume {
+func getAstarteCommonVolumes(cr *apiv1alpha2.Astarte, security apiv1alpha2.AstartePodSecuritySpec) []v1.Volume {
 	ret := []v1.Volume{
 		v1.Volume{
 			Name: "beam-config",
@@ -336,23 +392,29 @@ func getAstarteCommonVolumes(cr *apiv1alpha1.Astarte) []v1.Volume {
 			}},
 		},
 	}
+	if isHardened(security) {
+		ret = append(ret, v1.Volume{
+			Name:         "beam-mutable",
+			VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}},
+		})
+	}
 
 	return ret
 }
 
-func getVersionForAstarteComponent(cr *apiv1alpha1.Astarte, componentVersion string) string {
+func getVersionForAstarteComponent(cr *apiv1alpha2.Astarte, componentVersion string) string {
 	if componentVersion != "" {
 		return componentVersion
 	}
 	return cr.Spec.Version
 }
 
-func getSemanticVersionForAstarteComponent(cr *apiv1alpha1.Astarte, componentVersion string) *semver.Version {
+func getSemanticVersionForAstarteComponent(cr *apiv1alpha2.Astarte, componentVersion string) *semver.Version {
 	semVer, _ := semver.NewVersion(getVersionForAstarteComponent(cr, componentVersion))
 	return semVer
 }
 
-func getAstarteCommonVolumeMounts() []v1.VolumeMount {
+func getAstarteCommonVolumeMounts(security apiv1alpha2.AstartePodSecuritySpec) []v1.VolumeMount {
 	ret := []v1.VolumeMount{
 		v1.VolumeMount{
 			Name:      "beam-config",
@@ -360,19 +422,173 @@ func getAstarteCommonVolumeMounts() []v1.VolumeMount {
 			ReadOnly:  true,
 		},
 	}
+	if isHardened(security) {
+		ret = append(ret, v1.VolumeMount{
+			Name:      "beam-mutable",
+			MountPath: "/beammutable",
+		})
+	}
 
 	return ret
 }
 
-func getAffinityForClusteredResource(appLabel string, resource apiv1alpha1.AstarteGenericClusteredResource) *v1.Affinity {
+func getAffinityForClusteredResource(appLabel string, resource apiv1alpha2.AstarteGenericClusteredResource) *v1.Affinity {
 	affinity := resource.CustomAffinity
-	if affinity == nil && pointy.BoolValue(resource.AntiAffinity, true) {
-		affinity = getStandardAntiAffinityForAppLabel(appLabel)
+	if affinity == nil {
+		affinity = getAntiAffinityForAppLabel(appLabel, getAntiAffinityMode(resource))
 	}
 	return affinity
 }
 
-func getAstarteImageForClusteredResource(defaultImageName string, resource apiv1alpha1.AstarteGenericClusteredResource, cr *apiv1alpha1.Astarte) string {
+func getAntiAffinityMode(resource apiv1alpha2.AstarteGenericClusteredResource) apiv1alpha2.AstarteAntiAffinityMode {
+	if resource.AntiAffinityMode != "" {
+		return resource.AntiAffinityMode
+	}
+	if pointy.BoolValue(resource.AntiAffinity, true) {
+		return apiv1alpha2.AntiAffinityRequiredHost
+	}
+	return apiv1alpha2.AntiAffinityNone
+}
+
+// getPodTemplateObjectMeta returns the Pod Template metadata of a Component. User-provided labels never override
+// the Operator's ones, as those are used in Selectors.
+func getPodTemplateObjectMeta(labels map[string]string, scheduling apiv1alpha2.AstartePodSchedulingSpec,
+	security apiv1alpha2.AstartePodSecuritySpec) metav1.ObjectMeta {
+	podLabels := map[string]string{}
+	for k, v := range scheduling.PodLabels {
+		podLabels[k] = v
+	}
+	for k, v := range labels {
+		podLabels[k] = v
+	}
+
+	podAnnotations := scheduling.PodAnnotations
+	if security.SeccompProfile != "" {
+		podAnnotations = map[string]string{}
+		for k, v := range scheduling.PodAnnotations {
+			podAnnotations[k] = v
+		}
+		podAnnotations[v1.SeccompPodAnnotationKey] = security.SeccompProfile
+	}
+
+	return metav1.ObjectMeta{
+		Labels:      podLabels,
+		Annotations: podAnnotations,
+	}
+}
+
+func applyPodSchedulingSpec(ps *v1.PodSpec, scheduling apiv1alpha2.AstartePodSchedulingSpec) {
+	ps.NodeSelector = scheduling.NodeSelector
+	ps.Tolerations = scheduling.Tolerations
+	ps.PriorityClassName = scheduling.PriorityClassName
+	ps.TopologySpreadConstraints = scheduling.TopologySpreadConstraints
+}
+
+// applyPodSecuritySpec sets the security contexts of a Pod Spec, whose first container is the Component's main one.
+// Contexts which are not specified are left untouched, so that Components can keep the ones they need to run.
+func applyPodSecuritySpec(ps *v1.PodSpec, security apiv1alpha2.AstartePodSecuritySpec) {
+	if security.PodSecurityContext != nil {
+		ps.SecurityContext = security.PodSecurityContext
+	}
+	if security.SecurityContext != nil && len(ps.Containers) > 0 {
+		ps.Containers[0].SecurityContext = security.SecurityContext
+	}
+}
+
+// isHardened returns whether the Pods of an Astarte service opted into the hardened security defaults
+func isHardened(security apiv1alpha2.AstartePodSecuritySpec) bool {
+	return pointy.BoolValue(security.Hardened, false)
+}
+
+// getHardenedPodSecuritySpec returns the security settings of Astarte's Elixir services: when hardened, unless
+// specified otherwise, they run as an unprivileged user without any capability, and with the runtime's default
+// seccomp profile.
+func getHardenedPodSecuritySpec(security apiv1alpha2.AstartePodSecuritySpec) apiv1alpha2.AstartePodSecuritySpec {
+	if !isHardened(security) {
+		return security
+	}
+	if security.PodSecurityContext == nil {
+		security.PodSecurityContext = &v1.PodSecurityContext{
+			RunAsNonRoot: pointy.Bool(true),
+			RunAsUser:    pointy.Int64(astarteServicesUserID),
+			RunAsGroup:   pointy.Int64(astarteServicesUserID),
+			FSGroup:      pointy.Int64(astarteServicesUserID),
+		}
+	}
+	if security.SecurityContext == nil {
+		security.SecurityContext = &v1.SecurityContext{
+			AllowPrivilegeEscalation: pointy.Bool(false),
+			Capabilities:             &v1.Capabilities{Drop: []v1.Capability{"ALL"}},
+		}
+	}
+	if security.SeccompProfile == "" {
+		security.SeccompProfile = v1.SeccompProfileRuntimeDefault
+	}
+	return security
+}
+
+// applyPodExtensionsSpec merges user-provided settings and containers into a Pod Spec, whose first container
+// is the Component's main one
+func applyPodExtensionsSpec(ps *v1.PodSpec, extensions apiv1alpha2.AstartePodExtensionsSpec) {
+	if len(ps.Containers) > 0 {
+		ps.Containers[0].Env = mergeEnvVars(ps.Containers[0].Env, extensions.AdditionalEnv)
+		ps.Containers[0].VolumeMounts = mergeVolumeMounts(ps.Containers[0].VolumeMounts, extensions.AdditionalVolumeMounts)
+		ps.Containers = append(ps.Containers[:1], mergeContainers(ps.Containers[1:], extensions.Sidecars)...)
+	}
+	ps.Volumes = mergeVolumes(ps.Volumes, extensions.AdditionalVolumes)
+	ps.InitContainers = mergeContainers(ps.InitContainers, extensions.InitContainers)
+}
+
+func mergeEnvVars(generated, additional []v1.EnvVar) []v1.EnvVar {
+	merged := append(generated[:len(generated):len(generated)], additional...)
+	return merged[:mergeByKey(len(merged), len(generated),
+		func(i int) string { return merged[i].Name }, func(dst, src int) { merged[dst] = merged[src] })]
+}
+
+func mergeVolumes(generated, additional []v1.Volume) []v1.Volume {
+	merged := append(generated[:len(generated):len(generated)], additional...)
+	return merged[:mergeByKey(len(merged), len(generated),
+		func(i int) string { return merged[i].Name }, func(dst, src int) { merged[dst] = merged[src] })]
+}
+
+func mergeVolumeMounts(generated, additional []v1.VolumeMount) []v1.VolumeMount {
+	merged := append(generated[:len(generated):len(generated)], additional...)
+	return merged[:mergeByKey(len(merged), len(generated),
+		func(i int) string { return merged[i].MountPath }, func(dst, src int) { merged[dst] = merged[src] })]
+}
+
+func mergeContainers(generated, additional []v1.Container) []v1.Container {
+	merged := append(generated[:len(generated):len(generated)], additional...)
+	return merged[:mergeByKey(len(merged), len(generated),
+		func(i int) string { return merged[i].Name }, func(dst, src int) { merged[dst] = merged[src] })]
+}
+
+// mergeByKey merges the items of a slice of the given length starting at index from, i.e. the additional ones
+// appended to the generated ones, into the items before them: an item replaces the previous one with the same key,
+// or is kept after them otherwise. key returns the key of the item at index i, and move copies the item at index src
+// to index dst. It returns the length of the merged slice. The slice must not share its backing array with the
+// generated items, unless there is nothing to merge.
+func mergeByKey(length, from int, key func(i int) string, move func(dst, src int)) int {
+	merged := from
+	for src := from; src < length; src++ {
+		dst := merged
+		for i := 0; i < merged; i++ {
+			if key(i) == key(src) {
+				dst = i
+				break
+			}
+		}
+		if dst != src {
+			move(dst, src)
+		}
+		if dst == merged {
+			merged++
+		}
+	}
+	return merged
+}
+
+func getAstarteImageForClusteredResource(defaultImageName string, resource apiv1alpha2.AstarteGenericClusteredResource, cr *apiv1alpha2.Astarte) string {
 	if resource.Image != "" {
 		return resource.Image
 	}
@@ -380,7 +596,7 @@ func getAstarteImageForClusteredResource(defaultImageName string, resource apiv1
 	return getAstarteImageFromChannel(defaultImageName, getVersionForAstarteComponent(cr, resource.Version), cr)
 }
 
-func getImageForClusteredResource(defaultImageName, defaultImageTag string, resource apiv1alpha1.AstarteGenericClusteredResource) string {
+func getImageForClusteredResource(defaultImageName, defaultImageTag string, resource apiv1alpha2.AstarteGenericClusteredResource) string {
 	image := fmt.Sprintf("%s:%s", defaultImageName, defaultImageTag)
 	if resource.Image != "" {
 		image = resource.Image
@@ -391,15 +607,15 @@ func getImageForClusteredResource(defaultImageName, defaultImageTag string, reso
 	return image
 }
 
-func getDataQueueCount(cr *apiv1alpha1.Astarte) int {
+func getDataQueueCount(cr *apiv1alpha2.Astarte) int {
 	return pointy.IntValue(cr.Spec.Components.DataUpdaterPlant.DataQueueCount, 128)
 }
 
-func getAppEngineAPIMaxResultslimit(cr *apiv1alpha1.Astarte) int {
+func getAppEngineAPIMaxResultslimit(cr *apiv1alpha2.Astarte) int {
 	return pointy.IntValue(cr.Spec.Components.AppengineAPI.MaxResultsLimit, 10000)
 }
 
-func getBaseAstarteAPIURL(cr *apiv1alpha1.Astarte) string {
+func getBaseAstarteAPIURL(cr *apiv1alpha2.Astarte) string {
 	scheme := "https"
 	if !pointy.BoolValue(cr.Spec.API.SSL, true) {
 		scheme = "http"
@@ -0,0 +1,59 @@
+package reconcile
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestMergeEnvVars(t *testing.T) {
+	generated := []v1.EnvVar{{Name: "RELEASE_NAME", Value: "housekeeping"}, {Name: "REPLACE_OS_VARS", Value: "true"}}
+
+	testCases := []struct {
+		name       string
+		additional []v1.EnvVar
+		expected   []v1.EnvVar
+	}{
+		{"none", nil, generated},
+		{"override", []v1.EnvVar{{Name: "REPLACE_OS_VARS", Value: "false"}},
+			[]v1.EnvVar{{Name: "RELEASE_NAME", Value: "housekeeping"}, {Name: "REPLACE_OS_VARS", Value: "false"}}},
+		{"append", []v1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}},
+			[]v1.EnvVar{{Name: "RELEASE_NAME", Value: "housekeeping"}, {Name: "REPLACE_OS_VARS", Value: "true"}, {Name: "LOG_LEVEL", Value: "debug"}}},
+		{"override and append", []v1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}, {Name: "RELEASE_NAME", Value: "hk"}, {Name: "LOG_LEVEL", Value: "info"}},
+			[]v1.EnvVar{{Name: "RELEASE_NAME", Value: "hk"}, {Name: "REPLACE_OS_VARS", Value: "true"}, {Name: "LOG_LEVEL", Value: "info"}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if merged := mergeEnvVars(generated, tc.additional); !reflect.DeepEqual(merged, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, merged)
+			}
+			if generated[0].Value != "housekeeping" || generated[1].Value != "true" {
+				t.Errorf("Generated Env Vars were modified: %v", generated)
+			}
+		})
+	}
+}
+
+func TestMergeVolumeMounts(t *testing.T) {
+	generated := []v1.VolumeMount{{Name: "beam-config", MountPath: "/beamconfig", ReadOnly: true}}
+	additional := []v1.VolumeMount{{Name: "custom-config", MountPath: "/beamconfig"}, {Name: "certs", MountPath: "/certs"}}
+
+	// Volume Mounts are keyed by their path, not by their name
+	expected := []v1.VolumeMount{{Name: "custom-config", MountPath: "/beamconfig"}, {Name: "certs", MountPath: "/certs"}}
+	if merged := mergeVolumeMounts(generated, additional); !reflect.DeepEqual(merged, expected) {
+		t.Errorf("Expected %v, got %v", expected, merged)
+	}
+}
+
+func TestMergeContainersWithoutGenerated(t *testing.T) {
+	if merged := mergeContainers(nil, nil); merged != nil {
+		t.Errorf("Expected no Containers, got %v", merged)
+	}
+
+	sidecars := []v1.Container{{Name: "proxy"}}
+	if merged := mergeContainers(nil, sidecars); !reflect.DeepEqual(merged, sidecars) {
+		t.Errorf("Expected %v, got %v", sidecars, merged)
+	}
+}
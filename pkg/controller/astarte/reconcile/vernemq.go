@@ -8,7 +8,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	semver "github.com/Masterminds/semver/v3"
-	apiv1alpha1 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha1"
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
 	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
 	"github.com/openlyinc/pointy"
 	appsv1 "k8s.io/api/apps/v1"
@@ -22,7 +22,7 @@ import (
 )
 
 // EnsureVerneMQ reconciles VerneMQ
-func EnsureVerneMQ(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Scheme) error {
+func EnsureVerneMQ(ctx context.Context, cr *apiv1alpha2.Astarte, c client.Client, scheme *runtime.Scheme) error {
 	//reqLogger := log.WithValues("Request.Namespace", cr.Namespace, "Request.Name", cr.Name)
 	statefulSetName := cr.Name + "-vernemq"
 	labels := map[string]string{"app": statefulSetName}
@@ -32,35 +32,43 @@ func EnsureVerneMQ(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Sch
 		return err
 	}
 
+	// The Disruption Budget follows the replicas of the Component, and goes away together with it
+	if err := ensurePodDisruptionBudget(ctx, statefulSetName, labels, cr.Spec.VerneMQ.AstarteGenericClusteredResource, cr, c, scheme); err != nil {
+		return err
+	}
+
 	// Ok. Shall we deploy?
-	if !pointy.BoolValue(cr.Spec.VerneMQ.GenericClusteredResource.Deploy, true) {
+	if !pointy.BoolValue(cr.Spec.VerneMQ.Deploy, true) {
 		log.Info("Skipping VerneMQ Deployment")
-		// Before returning - check if we shall clean up the StatefulSet.
-		// It is the only thing actually requiring resources, the rest will be cleaned up eventually when the
-		// Astarte resource is deleted.
+		// Before returning - clean up everything in the Component's inventory. The StatefulSet is looked up by
+		// name too, as it might predate the inventory.
 		theStatefulSet := &appsv1.StatefulSet{}
-		err := c.Get(context.TODO(), types.NamespacedName{Name: statefulSetName, Namespace: cr.Namespace}, theStatefulSet)
+		err := c.Get(ctx, types.NamespacedName{Name: statefulSetName, Namespace: cr.Namespace}, theStatefulSet)
 		if err == nil {
 			log.Info("Deleting previously existing VerneMQ StatefulSet, which is no longer needed")
-			if err = c.Delete(context.TODO(), theStatefulSet); err != nil {
+			if err := misc.DeleteOwnedResource(ctx, cr, theStatefulSet, c); err != nil {
 				return err
 			}
 		}
 
+		if err := pruneComponentInventory(ctx, "vernemq", cr, c); err != nil {
+			return err
+		}
+
 		// That would be all for today.
 		return nil
 	}
 
 	// Ensure we reconcile with the RBAC Roles, if needed.
 	if pointy.BoolValue(cr.Spec.RBAC, true) {
-		if err := reconcileStandardRBACForClusteringForApp(statefulSetName, getVerneMQPolicyRules(), cr, c, scheme); err != nil {
+		if err := reconcileStandardRBACForClusteringForApp(ctx, statefulSetName, "vernemq", getVerneMQPolicyRules(), cr, c, scheme); err != nil {
 			return err
 		}
 	}
 
 	// Good. Now, reconcile the service first of all.
 	service := &v1.Service{ObjectMeta: metav1.ObjectMeta{Name: statefulSetName, Namespace: cr.Namespace}}
-	if result, err := controllerutil.CreateOrUpdate(context.TODO(), c, service, func() error {
+	if result, err := applyComponentObject(ctx, "vernemq", cr, c, scheme, service, func() error {
 		if err := controllerutil.SetControllerReference(cr, service, scheme); err != nil {
 			return err
 		}
@@ -89,7 +97,7 @@ func EnsureVerneMQ(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Sch
 		}
 		return nil
 	}); err == nil {
-		logCreateOrUpdateOperationResult(result, cr, service)
+		logCreateOrUpdateOperationResult(ctx, result, cr, service)
 	} else {
 		return err
 	}
@@ -105,20 +113,23 @@ func EnsureVerneMQ(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Sch
 			MatchLabels: labels,
 		},
 		Template: v1.PodTemplateSpec{
-			ObjectMeta: metav1.ObjectMeta{
-				Labels: labels,
-			},
-			Spec: getVerneMQPodSpec(statefulSetName, dataVolumeName, cr),
+			ObjectMeta: getPodTemplateObjectMeta(labels, cr.Spec.VerneMQ.AstartePodSchedulingSpec, cr.Spec.VerneMQ.AstartePodSecuritySpec),
+			Spec:       getVerneMQPodSpec(statefulSetName, dataVolumeName, cr),
 		},
 	}
 
+	// Roll the Pods whenever the configuration or credentials they consume change
+	if err := setConfigurationChecksums(ctx, &statefulSetSpec.Template, cr, c); err != nil {
+		return err
+	}
+
 	if persistentVolumeClaim != nil {
 		statefulSetSpec.VolumeClaimTemplates = []v1.PersistentVolumeClaim{*persistentVolumeClaim}
 	}
 
 	// Build the StatefulSet
 	vmqStatefulSet := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: statefulSetName, Namespace: cr.Namespace}}
-	result, err := controllerutil.CreateOrUpdate(context.TODO(), c, vmqStatefulSet, func() error {
+	result, err := applyComponentObject(ctx, "vernemq", cr, c, scheme, vmqStatefulSet, func() error {
 		if err := controllerutil.SetControllerReference(cr, vmqStatefulSet, scheme); err != nil {
 			return err
 		}
@@ -126,7 +137,7 @@ func EnsureVerneMQ(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Sch
 		// Assign the Spec.
 		vmqStatefulSet.ObjectMeta.Labels = map[string]string{"component": "astarte"}
 		vmqStatefulSet.Spec = statefulSetSpec
-		vmqStatefulSet.Spec.Replicas = cr.Spec.VerneMQ.GenericClusteredResource.Replicas
+		vmqStatefulSet.Spec.Replicas = cr.Spec.VerneMQ.Replicas
 
 		return nil
 	})
@@ -134,11 +145,11 @@ func EnsureVerneMQ(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Sch
 		return err
 	}
 
-	logCreateOrUpdateOperationResult(result, cr, service)
+	logCreateOrUpdateOperationResult(ctx, result, cr, service)
 	return nil
 }
 
-func validateVerneMQDefinition(vmq *apiv1alpha1.AstarteVerneMQSpec) error {
+func validateVerneMQDefinition(vmq *apiv1alpha2.AstarteVerneMQSpec) error {
 	if vmq == nil {
 		return nil
 	}
@@ -157,7 +168,7 @@ func getVerneMQProbe() *v1.Probe {
 	}
 }
 
-func getVerneMQEnvVars(statefulSetName string, cr *apiv1alpha1.Astarte) []v1.EnvVar {
+func getVerneMQEnvVars(statefulSetName string, cr *apiv1alpha2.Astarte) []v1.EnvVar {
 	userCredentialsSecretName, userCredentialsSecretUsernameKey, userCredentialsSecretPasswordKey := misc.GetRabbitMQUserCredentialsSecret(cr)
 	rabbitMQHost, _ := misc.GetRabbitMQHostnameAndPort(cr)
 	dataQueueCount := getDataQueueCount(cr)
@@ -200,7 +211,7 @@ func getVerneMQEnvVars(statefulSetName string, cr *apiv1alpha1.Astarte) []v1.Env
 	}
 
 	c, _ := semver.NewConstraint(">= 0.11.0")
-	ver, _ := semver.NewVersion(getVersionForAstarteComponent(cr, cr.Spec.VerneMQ.GenericClusteredResource.Version))
+	ver, _ := semver.NewVersion(getVersionForAstarteComponent(cr, cr.Spec.VerneMQ.Version))
 	checkVersion, _ := ver.SetPrerelease("")
 
 	if c.Check(&checkVersion) {
@@ -214,9 +225,9 @@ func getVerneMQEnvVars(statefulSetName string, cr *apiv1alpha1.Astarte) []v1.Env
 	return envVars
 }
 
-func getVerneMQPodSpec(statefulSetName, dataVolumeName string, cr *apiv1alpha1.Astarte) v1.PodSpec {
+func getVerneMQPodSpec(statefulSetName, dataVolumeName string, cr *apiv1alpha2.Astarte) v1.PodSpec {
 	serviceAccountName := statefulSetName
-	if pointy.BoolValue(cr.Spec.RBAC, false) {
+	if !pointy.BoolValue(cr.Spec.RBAC, true) {
 		serviceAccountName = ""
 	}
 
@@ -224,7 +235,7 @@ func getVerneMQPodSpec(statefulSetName, dataVolumeName string, cr *apiv1alpha1.A
 		TerminationGracePeriodSeconds: pointy.Int64(30),
 		ServiceAccountName:            serviceAccountName,
 		ImagePullSecrets:              cr.Spec.ImagePullSecrets,
-		Affinity:                      getAffinityForClusteredResource(statefulSetName, cr.Spec.VerneMQ.GenericClusteredResource),
+		Affinity:                      getAffinityForClusteredResource(statefulSetName, cr.Spec.VerneMQ.AstarteGenericClusteredResource),
 		Containers: []v1.Container{
 			v1.Container{
 				Name: "vernemq",
@@ -235,7 +246,7 @@ func getVerneMQPodSpec(statefulSetName, dataVolumeName string, cr *apiv1alpha1.A
 					},
 				},
 				// Defaults to the custom image built in Astarte
-				Image:           getAstarteImageForClusteredResource("vernemq", cr.Spec.VerneMQ.GenericClusteredResource, cr),
+				Image:           getAstarteImageForClusteredResource("vernemq", cr.Spec.VerneMQ.AstarteGenericClusteredResource, cr),
 				ImagePullPolicy: getImagePullPolicy(cr),
 				Ports: []v1.ContainerPort{
 					v1.ContainerPort{Name: "mqtt-ssl", ContainerPort: 8883},
@@ -258,12 +269,16 @@ func getVerneMQPodSpec(statefulSetName, dataVolumeName string, cr *apiv1alpha1.A
 				},
 				LivenessProbe:  getVerneMQProbe(),
 				ReadinessProbe: getVerneMQProbe(),
-				Resources:      cr.Spec.VerneMQ.GenericClusteredResource.Resources,
+				Resources:      cr.Spec.VerneMQ.Resources,
 				Env:            getVerneMQEnvVars(statefulSetName, cr),
 			},
 		},
 	}
 
+	applyPodSchedulingSpec(&ps, cr.Spec.VerneMQ.AstartePodSchedulingSpec)
+	applyPodExtensionsSpec(&ps, cr.Spec.VerneMQ.AstartePodExtensionsSpec)
+	applyPodSecuritySpec(&ps, cr.Spec.VerneMQ.AstartePodSecuritySpec)
+
 	return ps
 }
 
@@ -9,7 +9,7 @@ import (
 	"net/url"
 	"time"
 
-	apiv1alpha1 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha1"
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
 	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/controller/astarte/reconcile"
 	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
 	"github.com/openlyinc/pointy"
@@ -18,7 +18,6 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/portforward"
 	"k8s.io/client-go/transport/spdy"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -29,29 +28,30 @@ import (
 const landing011Version string = "0.11.0-beta.1"
 
 // blindly upgrades to 0.11. Invokable only by the upgrade logic
-func upgradeTo011(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Scheme) error {
+func upgradeTo011(ctx context.Context, cr *apiv1alpha2.Astarte, c client.Client, scheme *runtime.Scheme, steps *upgradeStepTracker) error {
 	// Follow the script!
 	reqLogger := log.WithValues("Request.Namespace", cr.Namespace, "Request.Name", cr.Name)
 	reqLogger.Info("Upgrading Astarte to the 0.11.x series. The cluster might become partially unresponsive during the process")
 	reqLogger.Info("The broker will be brought down during reconciliation. Over the upgrade process, Devices won't be able to connect")
 
 	// First, bring down VerneMQ by putting its replicas to 0, and wait until it is settled.
+	steps.begin("ShutdownBroker")
 	verneMQStatefulSetName := cr.Name + "-vernemq"
 	verneMQStatefulSet := &appsv1.StatefulSet{}
-	if err := c.Get(context.TODO(), types.NamespacedName{Name: verneMQStatefulSetName, Namespace: cr.Namespace}, verneMQStatefulSet); err != nil {
-		return fmt.Errorf("Could not retrieve VerneMQ statefulset: %v", err)
+	if err := c.Get(ctx, types.NamespacedName{Name: verneMQStatefulSetName, Namespace: cr.Namespace}, verneMQStatefulSet); err != nil {
+		return fmt.Errorf("Could not retrieve VerneMQ statefulset: %w", err)
 	}
 	verneMQStatefulSet.Spec.Replicas = pointy.Int32(0)
 	reqLogger.Info("Bringing down the broker to prevent data loss and mismatches. Devices won't be able to connect until the next reconciliation.")
-	if err := c.Update(context.TODO(), verneMQStatefulSet); err != nil {
-		return fmt.Errorf("Could not downscale VerneMQ statefulset: %v", err)
+	if err := c.Update(ctx, verneMQStatefulSet); err != nil {
+		return fmt.Errorf("Could not downscale VerneMQ statefulset: %w", err)
 	}
 
 	reqLogger.Info("Waiting for the broker to go down...")
 	// Now wait
-	if err := wait.Poll(retryInterval, timeout, func() (done bool, err error) {
+	if err := poll(ctx, retryInterval, timeout, func() (done bool, err error) {
 		statefulSet := &appsv1.StatefulSet{}
-		if err = c.Get(context.TODO(), types.NamespacedName{Name: verneMQStatefulSetName, Namespace: cr.Namespace}, statefulSet); err != nil {
+		if err = c.Get(ctx, types.NamespacedName{Name: verneMQStatefulSetName, Namespace: cr.Namespace}, statefulSet); err != nil {
 			return false, err
 		}
 
@@ -61,8 +61,9 @@ func upgradeTo011(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Sche
 
 		return true, nil
 	}); err != nil {
-		return fmt.Errorf("Failed in waiting for VerneMQ statefulset to shutdown: %v", err)
+		return fmt.Errorf("Failed in waiting for VerneMQ statefulset to shutdown: %w", err)
 	}
+	misc.RecordEvent(ctx, cr, misc.EventReasonUpgradeStepCompleted, "Broker shut down")
 
 	// It is now time to reconcile selectively Housekeeping and Housekeeping API to a safe landing (0.11.0-beta.1 now).
 	// Also, we want to bring up exactly one Replica of each at this time.
@@ -70,28 +71,31 @@ func upgradeTo011(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Sche
 	// Version enforcement is done to ensure that jump upgrades will be performed sequentially.
 	// Also, given VerneMQ is shutdown at the moment, we give Housekeeping (backend) more juice temporarily by adding to its resource pool
 	// VerneMQ's resources. When reconciling later, everything should just settle automagically.
+	steps.begin("MigrateDatabase")
 	reqLogger.Info("Upgrading Housekeeping and migrating the Database...")
 	housekeepingBackend := cr.Spec.Components.Housekeeping.Backend.DeepCopy()
 	housekeepingBackend.Version = landing011Version
 	housekeepingBackend.Replicas = pointy.Int32(1)
-	if misc.IsResourceRequirementsExplicit(cr.Spec.VerneMQ.GenericClusteredResource.Resources) {
-		resourceRequirements := misc.GetResourcesForAstarteComponent(cr, housekeepingBackend.Resources, apiv1alpha1.Housekeeping)
-		resourceRequirements.Requests.Cpu().Add(*cr.Spec.VerneMQ.GenericClusteredResource.Resources.Requests.Cpu())
-		resourceRequirements.Requests.Memory().Add(*cr.Spec.VerneMQ.GenericClusteredResource.Resources.Requests.Memory())
-		resourceRequirements.Limits.Cpu().Add(*cr.Spec.VerneMQ.GenericClusteredResource.Resources.Limits.Cpu())
-		resourceRequirements.Limits.Memory().Add(*cr.Spec.VerneMQ.GenericClusteredResource.Resources.Limits.Memory())
+	if misc.IsResourceRequirementsExplicit(cr.Spec.VerneMQ.Resources) {
+		resourceRequirements := misc.GetResourcesForAstarteComponent(cr, housekeepingBackend.Resources, apiv1alpha2.Housekeeping)
+		resourceRequirements.Requests.Cpu().Add(*cr.Spec.VerneMQ.Resources.Requests.Cpu())
+		resourceRequirements.Requests.Memory().Add(*cr.Spec.VerneMQ.Resources.Requests.Memory())
+		resourceRequirements.Limits.Cpu().Add(*cr.Spec.VerneMQ.Resources.Limits.Cpu())
+		resourceRequirements.Limits.Memory().Add(*cr.Spec.VerneMQ.Resources.Limits.Memory())
 
 		// This way, on the next call to GetResourcesForAstarteComponent, these resources will be returned as explicitly stated
 		// in the original spec.
 		housekeepingBackend.Resources = resourceRequirements
 	}
-	if err := reconcile.EnsureAstarteGenericBackend(cr, *housekeepingBackend, apiv1alpha1.Housekeeping, c, scheme); err != nil {
+	if err := reconcile.EnsureAstarteGenericBackend(ctx, cr, *housekeepingBackend, apiv1alpha2.Housekeeping, c, scheme); err != nil {
 		return err
 	}
 	housekeepingAPI := cr.Spec.Components.Housekeeping.API.DeepCopy()
-	housekeepingAPI.GenericClusteredResource.Replicas = pointy.Int32(1)
-	housekeepingAPI.GenericClusteredResource.Version = landing011Version
-	if err := reconcile.EnsureAstarteGenericAPI(cr, *housekeepingAPI, apiv1alpha1.HousekeepingAPI, c, scheme); err != nil {
+	housekeepingAPI.Replicas = pointy.Int32(1)
+	// Replicas must be enforced during the upgrade: the Autoscaler will be restored by the standard reconciliation
+	housekeepingAPI.Autoscaling = nil
+	housekeepingAPI.Version = landing011Version
+	if err := reconcile.EnsureAstarteGenericAPI(ctx, cr, *housekeepingAPI, apiv1alpha2.HousekeepingAPI, c, scheme); err != nil {
 		return err
 	}
 
@@ -99,9 +103,9 @@ func upgradeTo011(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Sche
 	// weird states such as CrashLoopBackoff, we wait almost forever
 	weirdFailuresCount := 0
 	weirdFailuresThreshold := 10
-	if err := wait.Poll(retryInterval, time.Hour, func() (done bool, err error) {
+	if err := poll(ctx, retryInterval, misc.GetTimeouts().Upgrade, func() (done bool, err error) {
 		deployment := &appsv1.Deployment{}
-		if err = c.Get(context.TODO(), types.NamespacedName{Name: cr.Name + "-housekeeping-api", Namespace: cr.Namespace}, deployment); err != nil {
+		if err = c.Get(ctx, types.NamespacedName{Name: cr.Name + "-housekeeping-api", Namespace: cr.Namespace}, deployment); err != nil {
 			weirdFailuresCount++
 			if weirdFailuresCount > weirdFailuresThreshold {
 				// Something is off.
@@ -118,9 +122,9 @@ func upgradeTo011(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Sche
 		}
 
 		// Ensure we aren't in the position where Housekeeping itself is crashing.
-		housekeepingComponent := apiv1alpha1.Housekeeping
+		housekeepingComponent := apiv1alpha2.Housekeeping
 		podList := &v1.PodList{}
-		if err = c.List(context.TODO(), podList, client.InNamespace(cr.Namespace),
+		if err = c.List(ctx, podList, client.InNamespace(cr.Namespace),
 			client.MatchingLabels{"astarte-component": housekeepingComponent.DashedString()}); err != nil {
 			weirdFailuresCount++
 			if weirdFailuresCount > weirdFailuresThreshold {
@@ -163,13 +167,15 @@ func upgradeTo011(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Sche
 
 		return false, nil
 	}); err != nil {
-		return fmt.Errorf("Failed in waiting for Housekeeping deployment and migrations to go up: %v", err)
+		return fmt.Errorf("Failed in waiting for Housekeeping deployment and migrations to go up: %w", err)
 	}
 	reqLogger.Info("Database successfully migrated!")
+	misc.RecordEvent(ctx, cr, misc.EventReasonUpgradeStepCompleted, "Database migrated by Housekeeping %s", landing011Version)
 
+	steps.begin("DrainRabbitMQQueues")
 	// We might also find out whether the queue has been entirely drained, so we don't lose
 	// data. If we're deployed externally, we have to initiate a port forward.
-	rmqHost, rmqUser, rmqPass, err := misc.GetRabbitMQCredentialsFor(cr, c)
+	rmqHost, rmqUser, rmqPass, err := misc.GetRabbitMQCredentialsFor(ctx, cr, c)
 	var fw *portforward.PortForwarder
 	var stopChannel chan struct{} = nil
 	if err != nil {
@@ -223,10 +229,11 @@ func upgradeTo011(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Sche
 	// Get the 0.10 queue state
 	httpClient := &http.Client{}
 	req, _ := http.NewRequest("GET", "http://"+rmqHost+":15672/api/queues/%2F/vmq_all", nil)
+	req = req.WithContext(ctx)
 	req.SetBasicAuth(rmqUser, rmqPass)
 
 	// Wait up to a minute, otherwise restart
-	if err := wait.Poll(5*time.Second, time.Minute, func() (done bool, err error) {
+	if err := poll(ctx, 5*time.Second, time.Minute, func() (done bool, err error) {
 		if resp, err := httpClient.Do(req); err == nil {
 			defer resp.Body.Close()
 			respBody, _ := ioutil.ReadAll(resp.Body)
@@ -251,6 +258,7 @@ func upgradeTo011(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Sche
 	}
 
 	reqLogger.Info("RabbitMQ Data Queue(s) drained")
+	misc.RecordEvent(ctx, cr, misc.EventReasonUpgradeStepCompleted, "RabbitMQ Data Queues drained")
 
 	if stopChannel != nil {
 		// Close the forwarder
@@ -261,16 +269,17 @@ func upgradeTo011(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Sche
 	// to ensure the consistency of RabbitMQ queues.
 	// Again, same thing as before: hook to a known version. There's no need to add more
 	// resources to DUP as it doesn't need them to perform this operation, and most of all it should have enough sauce already.
+	steps.begin("UpgradeRabbitMQQueuesLayout")
 	reqLogger.Info("Ensuring new RabbitMQ Queue Layout through Data Updater Plant...")
 	dataUpdaterPlant := cr.Spec.Components.DataUpdaterPlant.DeepCopy()
-	dataUpdaterPlant.GenericClusteredResource.Version = landing011Version
-	if err := reconcile.EnsureAstarteGenericBackend(cr, dataUpdaterPlant.GenericClusteredResource, apiv1alpha1.DataUpdaterPlant, c, scheme); err != nil {
+	dataUpdaterPlant.Version = landing011Version
+	if err := reconcile.EnsureAstarteGenericBackend(ctx, cr, dataUpdaterPlant.AstarteGenericClusteredResource, apiv1alpha2.DataUpdaterPlant, c, scheme); err != nil {
 		return err
 	}
 	// Again, the operation should be pretty normal. Wait with standard timeouts here
-	if err := wait.Poll(retryInterval, timeout, func() (done bool, err error) {
+	if err := poll(ctx, retryInterval, timeout, func() (done bool, err error) {
 		deployment := &appsv1.Deployment{}
-		if err = c.Get(context.TODO(), types.NamespacedName{Name: cr.Name + "-data-updater-plant", Namespace: cr.Namespace}, deployment); err != nil {
+		if err = c.Get(ctx, types.NamespacedName{Name: cr.Name + "-data-updater-plant", Namespace: cr.Namespace}, deployment); err != nil {
 			return false, err
 		}
 
@@ -280,31 +289,36 @@ func upgradeTo011(cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Sche
 
 		return false, nil
 	}); err != nil {
-		return fmt.Errorf("Failed in waiting for Data Updater Plant to come up: %v", err)
+		return fmt.Errorf("Failed in waiting for Data Updater Plant to come up: %w", err)
 	}
 	reqLogger.Info("RabbitMQ queues layout upgrade successful!")
+	misc.RecordEvent(ctx, cr, misc.EventReasonUpgradeStepCompleted, "RabbitMQ Queues layout upgraded by Data Updater Plant %s", landing011Version)
 	reqLogger.Info("Your Astarte cluster has been successfully upgraded to the 0.11.x series!")
 
 	// This is it. Do not bring up VerneMQ or anything: the reconciliation will now do the right thing with the right versions.
 	// On the other hand, as the update successfully completed, increase the Astarte version in the status to ensure we don't
 	// go through this twice.
 	cr.Status.AstarteVersion = landing011Version
-	if err := c.Status().Update(context.TODO(), cr); err != nil {
+	cr.SetCondition(apiv1alpha2.AstarteConditionUpgrading, v1.ConditionFalse, apiv1alpha2.ReasonUpgradeSucceeded,
+		fmt.Sprintf("Astarte was upgraded to %s", landing011Version))
+	if err := c.Status().Update(ctx, cr); err != nil {
 		reqLogger.Error(err, "Failed to update Astarte status. The Operator might misbehave")
 		return err
 	}
+	misc.RecordEvent(ctx, cr, misc.EventReasonUpgradeSucceeded, "Astarte was upgraded to %s", landing011Version)
 
 	// Just to be sure, scale down Housekeeping to 0 replicas. If we're *really* tight on resources, it might be that
 	// the additional pool prevents other pods from coming up.
+	steps.begin("RestoreEnvironment")
 	reqLogger.Info("Restoring original environment and waiting for cluster to settle...")
 	housekeepingBackend.Replicas = pointy.Int32(0)
-	if err := reconcile.EnsureAstarteGenericBackend(cr, *housekeepingBackend, apiv1alpha1.Housekeeping, c, scheme); err != nil {
+	if err := reconcile.EnsureAstarteGenericBackend(ctx, cr, *housekeepingBackend, apiv1alpha2.Housekeeping, c, scheme); err != nil {
 		return err
 	}
 	// Wait for it to go down, then we should be good to go.
-	if err := wait.Poll(retryInterval, timeout, func() (done bool, err error) {
+	if err := poll(ctx, retryInterval, timeout, func() (done bool, err error) {
 		deployment := &appsv1.Deployment{}
-		if err = c.Get(context.TODO(), types.NamespacedName{Name: cr.Name + "-housekeeping", Namespace: cr.Namespace}, deployment); err != nil {
+		if err = c.Get(ctx, types.NamespacedName{Name: cr.Name + "-housekeeping", Namespace: cr.Namespace}, deployment); err != nil {
 			return false, err
 		}
 
@@ -2,13 +2,17 @@ package upgrade
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	semver "github.com/Masterminds/semver/v3"
-	apiv1alpha1 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha1"
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/metrics"
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -24,15 +28,19 @@ const (
 )
 
 // EnsureAstarteUpgrade ensures that CR with requested newVersion will be upgraded from oldVersion, if needed.
-func EnsureAstarteUpgrade(oldVersion, newVersion *semver.Version, cr *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Scheme) error {
+func EnsureAstarteUpgrade(ctx context.Context, oldVersion, newVersion *semver.Version, cr *apiv1alpha2.Astarte, c client.Client, scheme *runtime.Scheme) error {
 	// Check 0.10.x -> 0.11.x constraint
-	transitionCheck, err := validateConstraintAndPrepareUpgrade(oldVersion, newVersion, "~0.10.0", ">= 0.11.0", cr, c)
+	transitionCheck, err := validateConstraintAndPrepareUpgrade(ctx, oldVersion, newVersion, "~0.10.0", ">= 0.11.0", cr, c)
 	if err != nil {
 		return err
 	}
 	if transitionCheck {
 		// Perform upgrade
-		if err := upgradeTo011(cr, c, scheme); err != nil {
+		steps := &upgradeStepTracker{cr: cr}
+		err := upgradeTo011(ctx, cr, c, scheme, steps)
+		steps.end(err)
+		if err != nil {
+			setUpgradeFailed(ctx, cr, c, err)
 			return err
 		}
 	}
@@ -41,7 +49,7 @@ func EnsureAstarteUpgrade(oldVersion, newVersion *semver.Version, cr *apiv1alpha
 	return nil
 }
 
-func validateConstraintAndPrepareUpgrade(oldVersion, newVersion *semver.Version, oldConstraintString, newConstraintString string, cr *apiv1alpha1.Astarte, c client.Client) (bool, error) {
+func validateConstraintAndPrepareUpgrade(ctx context.Context, oldVersion, newVersion *semver.Version, oldConstraintString, newConstraintString string, cr *apiv1alpha2.Astarte, c client.Client) (bool, error) {
 	oldConstraint, err := semver.NewConstraint(oldConstraintString)
 	if err != nil {
 		return false, err
@@ -65,9 +73,12 @@ func validateConstraintAndPrepareUpgrade(oldVersion, newVersion *semver.Version,
 		// Set the Reconciliation Phase to Upgrading
 		reqLogger := log.WithValues("Request.Namespace", cr.Namespace, "Request.Name", cr.Name)
 		reqLogger.Info("Upgrade found, will start Upgrade routine")
-		cr.Status.ReconciliationPhase = apiv1alpha1.ReconciliationPhaseUpgrading
+		misc.RecordEvent(ctx, cr, misc.EventReasonUpgradeStarted, "Upgrading Astarte from %s to %s", cr.Status.AstarteVersion, cr.Spec.Version)
+		cr.Status.ReconciliationPhase = apiv1alpha2.ReconciliationPhaseUpgrading
+		cr.SetCondition(apiv1alpha2.AstarteConditionUpgrading, v1.ConditionTrue, apiv1alpha2.ReasonUpgradeInProgress,
+			fmt.Sprintf("Upgrading Astarte from %s to %s", cr.Status.AstarteVersion, cr.Spec.Version))
 		// Update the status
-		if err := c.Status().Update(context.TODO(), cr); err != nil {
+		if err := c.Status().Update(ctx, cr); err != nil {
 			reqLogger.Error(err, "Failed to update Astarte Reconciliation Phase status. Not dying for this, though")
 			// That's it - no point in failing here.
 		}
@@ -76,6 +87,44 @@ func validateConstraintAndPrepareUpgrade(oldVersion, newVersion *semver.Version,
 	return oldConstraintValidated && newConstraintValidated, nil
 }
 
+func setUpgradeFailed(ctx context.Context, cr *apiv1alpha2.Astarte, c client.Client, upgradeErr error) {
+	misc.RecordWarningEvent(ctx, cr, misc.EventReasonUpgradeFailed, "Upgrade to %s failed: %v", cr.Spec.Version, upgradeErr)
+	cr.SetCondition(apiv1alpha2.AstarteConditionUpgrading, v1.ConditionFalse, apiv1alpha2.ReasonUpgradeFailed, upgradeErr.Error())
+	if err := c.Status().Update(ctx, cr); err != nil {
+		log.Error(err, "Failed to update Astarte Upgrading condition. Not dying for this, though",
+			"Request.Namespace", cr.Namespace, "Request.Name", cr.Name)
+	}
+}
+
+// upgradeStepTracker times the steps of an upgrade, and exposes them as metrics
+type upgradeStepTracker struct {
+	cr    *apiv1alpha2.Astarte
+	step  string
+	start time.Time
+}
+
+// begin ends the current step, which succeeded, and starts the next one
+func (t *upgradeStepTracker) begin(step string) {
+	t.end(nil)
+	t.step, t.start = step, time.Now()
+}
+
+// end ends the current step, which failed if err is not nil
+func (t *upgradeStepTracker) end(err error) {
+	if t.step == "" {
+		return
+	}
+	metrics.ObserveUpgradeStep(t.cr, t.step, time.Since(t.start), err == nil)
+	t.step = ""
+}
+
+// poll checks condition every interval until it's done, or until timeout elapses or ctx is done
+func poll(ctx context.Context, interval, timeout time.Duration, condition wait.ConditionFunc) error {
+	pollCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return wait.PollUntil(interval, condition, pollCtx.Done())
+}
+
 func getSpecialHousekeepingMigrationProbe(path string) *v1.Probe {
 	// This is a special migration probe that handles longer timeouts due to migrations.
 	// Migrations can take an insane amount of time, as such we should take this into account.
@@ -8,7 +8,7 @@ import (
 	"strings"
 
 	voyager "github.com/astarte-platform/astarte-kubernetes-operator/external/voyager/v1beta1"
-	apiv1alpha1 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha1"
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
 	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
 	"github.com/openlyinc/pointy"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -19,15 +19,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-func ensureAPIIngress(cr *apiv1alpha1.AstarteVoyagerIngress, parent *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Scheme) error {
+func ensureAPIIngress(ctx context.Context, cr *apiv1alpha2.AstarteVoyagerIngress, parent *apiv1alpha2.Astarte, c client.Client, scheme *runtime.Scheme) error {
 	ingressName := getAPIIngressName(cr)
-	if !pointy.BoolValue(cr.Spec.API.GenericIngressSpec.Deploy, true) {
+	if !pointy.BoolValue(cr.Spec.API.Deploy, true) {
 		// We're not deploying the Ingress, so we're stopping here.
 		// However, maybe we have an Ingress to clean up?
 		ingress := &voyager.Ingress{}
-		if err := c.Get(context.TODO(), types.NamespacedName{Name: ingressName, Namespace: cr.Namespace}, ingress); err == nil {
+		if err := c.Get(ctx, types.NamespacedName{Name: ingressName, Namespace: cr.Namespace}, ingress); err == nil {
 			// Delete the ingress
-			if err := c.Delete(context.TODO(), ingress); err != nil {
+			if err := misc.DeleteOwnedResource(ctx, cr, ingress, c); err != nil {
 				return err
 			}
 		}
@@ -43,20 +43,25 @@ func ensureAPIIngress(cr *apiv1alpha1.AstarteVoyagerIngress, parent *apiv1alpha1
 		// Tunnel is for websockets - 10m is more then enough
 		voyager.DefaultsTimeOut: `{"tunnel": "10m"}`,
 	}
-	if cr.Spec.API.GenericIngressSpec.Replicas != nil {
-		annotations[voyager.Replicas] = strconv.Itoa(int(pointy.Int32Value(cr.Spec.API.GenericIngressSpec.Replicas, 1)))
+	if cr.Spec.API.Replicas != nil {
+		annotations[voyager.Replicas] = strconv.Itoa(int(pointy.Int32Value(cr.Spec.API.Replicas, 1)))
 	}
 	if pointy.BoolValue(cr.Spec.API.Cors, false) {
 		annotations[voyager.CORSEnabled] = "true"
 	}
-	if cr.Spec.API.GenericIngressSpec.NodeSelector != "" {
-		annotations[voyager.NodeSelector] = cr.Spec.API.GenericIngressSpec.NodeSelector
+	if len(cr.Spec.API.NodeSelector) > 0 {
+		// Voyager expects the Node Selector as a JSON object
+		nS, err := json.Marshal(cr.Spec.API.NodeSelector)
+		if err != nil {
+			return err
+		}
+		annotations[voyager.NodeSelector] = string(nS)
 	}
-	if cr.Spec.API.GenericIngressSpec.Type != "" {
-		annotations[voyager.LBType] = cr.Spec.API.GenericIngressSpec.Type
+	if cr.Spec.API.Type != "" {
+		annotations[voyager.LBType] = cr.Spec.API.Type
 	}
-	if cr.Spec.API.GenericIngressSpec.LoadBalancerIP != "" {
-		annotations[voyager.LoadBalancerIP] = cr.Spec.API.GenericIngressSpec.LoadBalancerIP
+	if cr.Spec.API.LoadBalancerIP != "" {
+		annotations[voyager.LoadBalancerIP] = cr.Spec.API.LoadBalancerIP
 	}
 	if pointy.BoolValue(parent.Spec.API.SSL, true) || pointy.BoolValue(parent.Spec.Components.Dashboard.SSL, true) {
 		// Add safe-SSL options
@@ -65,9 +70,9 @@ func ensureAPIIngress(cr *apiv1alpha1.AstarteVoyagerIngress, parent *apiv1alpha1
 		annotations[voyager.HSTSIncludeSubDomains] = "true"
 		annotations[voyager.HSTSMaxAge] = "180"
 	}
-	if len(cr.Spec.API.GenericIngressSpec.AnnotationsService) > 0 {
+	if len(cr.Spec.API.AnnotationsService) > 0 {
 		// Marshal into a JSON, and call it a day.
-		aS, err := json.Marshal(cr.Spec.API.GenericIngressSpec.AnnotationsService)
+		aS, err := json.Marshal(cr.Spec.API.AnnotationsService)
 		if err != nil {
 			return err
 		}
@@ -83,11 +88,11 @@ func ensureAPIIngress(cr *apiv1alpha1.AstarteVoyagerIngress, parent *apiv1alpha1
 		ingressTLSs := []voyager.IngressTLS{}
 		apiProcessed, dashboardProcessed := false, false
 		// Check API first
-		if cr.Spec.API.GenericIngressSpec.TLSRef != nil {
-			ingressTLSs = append(ingressTLSs, voyager.IngressTLS{Ref: cr.Spec.API.GenericIngressSpec.TLSRef, Hosts: []string{parent.Spec.API.Host}})
+		if cr.Spec.API.TLSRef != nil {
+			ingressTLSs = append(ingressTLSs, voyager.IngressTLS{Ref: cr.Spec.API.TLSRef, Hosts: []string{parent.Spec.API.Host}})
 			apiProcessed = true
-		} else if cr.Spec.API.GenericIngressSpec.TLSSecret != "" {
-			ingressTLSs = append(ingressTLSs, voyager.IngressTLS{SecretName: cr.Spec.API.GenericIngressSpec.TLSSecret, Hosts: []string{parent.Spec.API.Host}})
+		} else if cr.Spec.API.TLSSecret != "" {
+			ingressTLSs = append(ingressTLSs, voyager.IngressTLS{SecretName: cr.Spec.API.TLSSecret, Hosts: []string{parent.Spec.API.Host}})
 			apiProcessed = true
 		}
 		// Then Dashboard - if needed
@@ -107,7 +112,7 @@ func ensureAPIIngress(cr *apiv1alpha1.AstarteVoyagerIngress, parent *apiv1alpha1
 		// Finally, let's see if we need to add anything to Let's Encrypt
 		if (!apiProcessed || !dashboardProcessed) && pointy.BoolValue(cr.Spec.Letsencrypt.Use, true) {
 			// Are we bootstrapping?
-			bootstrappingLE, err := isBootstrappingLEChallenge(cr, parent, c)
+			bootstrappingLE, err := isBootstrappingLEChallenge(ctx, cr, parent, c)
 			if err != nil {
 				return err
 			}
@@ -138,10 +143,10 @@ func ensureAPIIngress(cr *apiv1alpha1.AstarteVoyagerIngress, parent *apiv1alpha1
 
 	apiPaths := []voyager.HTTPIngressPath{}
 	// Create rules for all Astarte components
-	astarteComponents := []apiv1alpha1.AstarteComponent{apiv1alpha1.AppEngineAPI, apiv1alpha1.HousekeepingAPI, apiv1alpha1.PairingAPI, apiv1alpha1.RealmManagementAPI}
+	astarteComponents := []apiv1alpha2.AstarteComponent{apiv1alpha2.AppEngineAPI, apiv1alpha2.HousekeepingAPI, apiv1alpha2.PairingAPI, apiv1alpha2.RealmManagementAPI}
 	// Is the Dashboard deployed without a host?
-	if misc.IsAstarteComponentDeployed(parent, apiv1alpha1.Dashboard) && cr.Spec.Dashboard.Host == "" {
-		astarteComponents = append(astarteComponents, apiv1alpha1.Dashboard)
+	if misc.IsAstarteComponentDeployed(parent, apiv1alpha2.Dashboard) && cr.Spec.Dashboard.Host == "" {
+		astarteComponents = append(astarteComponents, apiv1alpha2.Dashboard)
 	}
 	for _, component := range astarteComponents {
 		if misc.IsAstarteComponentDeployed(parent, component) {
@@ -156,11 +161,11 @@ func ensureAPIIngress(cr *apiv1alpha1.AstarteVoyagerIngress, parent *apiv1alpha1
 	})
 
 	// Is the Dashboard deployed on a separate host?
-	if misc.IsAstarteComponentDeployed(parent, apiv1alpha1.Dashboard) && cr.Spec.Dashboard.Host != "" {
+	if misc.IsAstarteComponentDeployed(parent, apiv1alpha2.Dashboard) && cr.Spec.Dashboard.Host != "" {
 		rules = append(rules, voyager.IngressRule{
 			Host: cr.Spec.Dashboard.Host,
 			IngressRuleValue: voyager.IngressRuleValue{HTTP: &voyager.HTTPIngressRuleValue{
-				Paths: []voyager.HTTPIngressPath{getHTTPIngressPathForAstarteComponent(parent, apiv1alpha1.Dashboard)},
+				Paths: []voyager.HTTPIngressPath{getHTTPIngressPathForAstarteComponent(parent, apiv1alpha2.Dashboard)},
 			}},
 		})
 	}
@@ -169,7 +174,7 @@ func ensureAPIIngress(cr *apiv1alpha1.AstarteVoyagerIngress, parent *apiv1alpha1
 
 	// Reconcile the Ingress
 	ingress := &voyager.Ingress{ObjectMeta: metav1.ObjectMeta{Name: ingressName, Namespace: cr.Namespace}}
-	result, err := controllerutil.CreateOrUpdate(context.TODO(), c, ingress, func() error {
+	result, err := misc.Apply(ctx, c, scheme, ingress, func() error {
 		if err := controllerutil.SetControllerReference(cr, ingress, scheme); err != nil {
 			return err
 		}
@@ -180,13 +185,13 @@ func ensureAPIIngress(cr *apiv1alpha1.AstarteVoyagerIngress, parent *apiv1alpha1
 		return nil
 	})
 	if err == nil {
-		logCreateOrUpdateOperationResult(result, cr, ingress)
+		logCreateOrUpdateOperationResult(ctx, result, cr, ingress)
 	}
 
 	return err
 }
 
-func getHTTPIngressPathForAstarteComponent(parent *apiv1alpha1.Astarte, component apiv1alpha1.AstarteComponent) voyager.HTTPIngressPath {
+func getHTTPIngressPathForAstarteComponent(parent *apiv1alpha2.Astarte, component apiv1alpha2.AstarteComponent) voyager.HTTPIngressPath {
 	// Safe HTTP headers to add in responses.
 	backendSSLRules := []string{
 		"http-response add-header X-Frame-Options SAMEORIGIN",
@@ -211,10 +216,10 @@ func getHTTPIngressPathForAstarteComponent(parent *apiv1alpha1.Astarte, componen
 	}
 }
 
-func getAPIIngressName(cr *apiv1alpha1.AstarteVoyagerIngress) string {
+func getAPIIngressName(cr *apiv1alpha2.AstarteVoyagerIngress) string {
 	return cr.Name + "-api-ingress"
 }
 
-func isAPIIngressReady(cr *apiv1alpha1.AstarteVoyagerIngress, c client.Client) bool {
-	return isIngressReady(getAPIIngressName(cr), cr, c)
+func isAPIIngressReady(ctx context.Context, cr *apiv1alpha2.AstarteVoyagerIngress, c client.Client) bool {
+	return isIngressReady(ctx, getAPIIngressName(cr), cr, c)
 }
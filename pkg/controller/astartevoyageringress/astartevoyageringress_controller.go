@@ -6,11 +6,14 @@ import (
 	"time"
 
 	voyager "github.com/astarte-platform/astarte-kubernetes-operator/external/voyager/v1beta1"
-	apiv1alpha1 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha1"
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/metrics"
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -26,24 +29,45 @@ var log = logf.Log.WithName("controller_astartevoyageringress")
 // Add creates a new AstarteVoyagerIngress Controller and adds it to the Manager. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
-	return add(mgr, newReconciler(mgr))
+	ctx, err := misc.NewManagerContext(mgr)
+	if err != nil {
+		return err
+	}
+	return add(ctx, mgr, newReconciler(ctx, mgr))
 }
 
 // newReconciler returns a new reconcile.Reconciler
-func newReconciler(mgr manager.Manager) reconcile.Reconciler {
-	return &ReconcileAstarteVoyagerIngress{client: mgr.GetClient(), scheme: mgr.GetScheme()}
+func newReconciler(ctx context.Context, mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileAstarteVoyagerIngress{
+		ctx:      ctx,
+		recorder: mgr.GetEventRecorderFor("astarte-operator"),
+		client:   misc.NewTimeoutClient(mgr.GetClient(), misc.GetTimeouts().Operation),
+		scheme:   mgr.GetScheme(),
+	}
 }
 
-// add adds a new Controller to mgr with r as the reconcile.Reconciler
-func add(mgr manager.Manager, r reconcile.Reconciler) error {
+// add adds a new Controller to mgr with r as the reconcile.Reconciler. ctx bounds the lookups of its watches.
+func add(ctx context.Context, mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
 	c, err := controller.New("astartevoyageringress-controller", mgr, controller.Options{Reconciler: r})
 	if err != nil {
 		return err
 	}
 
-	// Watch for changes to primary resource AstarteVoyagerIngress
-	if err = c.Watch(&source.Kind{Type: &apiv1alpha1.AstarteVoyagerIngress{}}, &handler.EnqueueRequestForObject{}); err != nil {
+	// When watching namespaces chosen by a label selector, the whole cluster is watched: drop the events of other
+	// namespaces, and pick up Resources in namespaces as soon as they match
+	inSelectedNamespaces, err := misc.NewNamespaceSelectorPredicate(ctx, mgr.GetClient())
+	if err != nil {
+		return err
+	}
+	if err = misc.WatchSelectedNamespaces(ctx, c, mgr.GetClient(), &apiv1alpha2.AstarteVoyagerIngressList{}); err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource AstarteVoyagerIngress. Its status is written by the Operator only, and
+	// does not need to be reconciled.
+	if err = c.Watch(&source.Kind{Type: &apiv1alpha2.AstarteVoyagerIngress{}}, &handler.EnqueueRequestForObject{},
+		misc.IgnoreStatusUpdatesPredicate, inSelectedNamespaces); err != nil {
 		return err
 	}
 
@@ -72,8 +96,8 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		// Watch for changes to secondary resource Ingress and requeue the owner AstarteVoyagerIngress
 		if err = c.Watch(&source.Kind{Type: &voyager.Ingress{}}, &handler.EnqueueRequestForOwner{
 			IsController: true,
-			OwnerType:    &apiv1alpha1.AstarteVoyagerIngress{},
-		}); err != nil {
+			OwnerType:    &apiv1alpha2.AstarteVoyagerIngress{},
+		}, inSelectedNamespaces); err != nil {
 			c = nil
 			return nil
 		}
@@ -81,8 +105,8 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		// Watch for changes to secondary resource Ingress and requeue the owner AstarteVoyagerIngress
 		if err = c.Watch(&source.Kind{Type: &voyager.Certificate{}}, &handler.EnqueueRequestForOwner{
 			IsController: true,
-			OwnerType:    &apiv1alpha1.AstarteVoyagerIngress{},
-		}); err != nil {
+			OwnerType:    &apiv1alpha2.AstarteVoyagerIngress{},
+		}, inSelectedNamespaces); err != nil {
 			c = nil
 			return nil
 		}
@@ -101,6 +125,11 @@ type ReconcileAstarteVoyagerIngress struct {
 	client          client.Client
 	scheme          *runtime.Scheme
 	shouldReconcile bool
+	// Reconcile has no context of its own: all reconciliations derive from this one, which is cancelled when the
+	// Manager stops
+	ctx context.Context
+	// recorder emits Events on the reconciled Resources. Each reconciliation carries it in its context.
+	recorder record.EventRecorder
 }
 
 // Reconcile reads that state of the cluster for a AstarteVoyagerIngress object and makes changes based on the state read
@@ -116,14 +145,18 @@ func (r *ReconcileAstarteVoyagerIngress) Reconcile(request reconcile.Request) (r
 	}
 	reqLogger.Info("Reconciling AstarteVoyagerIngress")
 
+	ctx, cancel := context.WithTimeout(misc.WithEventRecorder(r.ctx, r.recorder), misc.GetTimeouts().Reconcile)
+	defer cancel()
+
 	// Fetch the AstarteVoyagerIngress instance
-	instance := &apiv1alpha1.AstarteVoyagerIngress{}
-	err := r.client.Get(context.TODO(), request.NamespacedName, instance)
+	instance := &apiv1alpha2.AstarteVoyagerIngress{}
+	err := r.client.Get(ctx, request.NamespacedName, instance)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			metrics.DeleteVoyagerIngressCertificateState(request.Namespace, request.Name)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -131,9 +164,10 @@ func (r *ReconcileAstarteVoyagerIngress) Reconcile(request reconcile.Request) (r
 	}
 
 	// Get the Astarte instance
-	astarte := &apiv1alpha1.Astarte{}
-	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: instance.Spec.Astarte, Namespace: instance.Namespace}, astarte); err != nil {
+	astarte := &apiv1alpha2.Astarte{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: instance.Spec.Astarte, Namespace: instance.Namespace}, astarte); err != nil {
 		if errors.IsNotFound(err) {
+			misc.RecordWarningEvent(ctx, instance, misc.EventReasonReconcileFailed, "Astarte %s could not be found", instance.Spec.Astarte)
 			d, _ := time.ParseDuration("30s")
 			return reconcile.Result{Requeue: true, RequeueAfter: d},
 				fmt.Errorf("The Astarte Instance %s associated to this Voyager Ingress object cannot be found", instance.Spec.Astarte)
@@ -143,17 +177,32 @@ func (r *ReconcileAstarteVoyagerIngress) Reconcile(request reconcile.Request) (r
 	}
 
 	// Start by reconciling the Certificate (if needed)
-	if err := ensureCertificate(instance, astarte, r.client, r.scheme); err != nil {
+	if err := ensureCertificate(ctx, instance, astarte, r.client, r.scheme); err != nil {
+		misc.RecordWarningEvent(ctx, instance, misc.EventReasonReconcileFailed, "%v", err)
 		return reconcile.Result{}, err
 	}
 
 	// Reconcile the API Ingress
-	if err := ensureAPIIngress(instance, astarte, r.client, r.scheme); err != nil {
+	if err := ensureAPIIngress(ctx, instance, astarte, r.client, r.scheme); err != nil {
+		misc.RecordWarningEvent(ctx, instance, misc.EventReasonReconcileFailed, "%v", err)
 		return reconcile.Result{}, err
 	}
 
 	// Reconcile the Broker Ingress
-	if err := ensureBrokerIngress(instance, astarte, r.client, r.scheme); err != nil {
+	if err := ensureBrokerIngress(ctx, instance, astarte, r.client, r.scheme); err != nil {
+		misc.RecordWarningEvent(ctx, instance, misc.EventReasonReconcileFailed, "%v", err)
+		return reconcile.Result{}, err
+	}
+
+	// Report what we observed
+	status, err := computeStatus(ctx, instance, astarte, r.client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	instance.Status = status
+	metrics.SetVoyagerIngressCertificateState(instance)
+	if err := r.client.Status().Update(ctx, instance); err != nil {
+		reqLogger.Error(err, "Failed to update AstarteVoyagerIngress status.")
 		return reconcile.Result{}, err
 	}
 
@@ -6,7 +6,8 @@ import (
 	"strconv"
 
 	voyager "github.com/astarte-platform/astarte-kubernetes-operator/external/voyager/v1beta1"
-	apiv1alpha1 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha1"
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
 	"github.com/openlyinc/pointy"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -16,15 +17,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-func ensureBrokerIngress(cr *apiv1alpha1.AstarteVoyagerIngress, parent *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Scheme) error {
+func ensureBrokerIngress(ctx context.Context, cr *apiv1alpha2.AstarteVoyagerIngress, parent *apiv1alpha2.Astarte, c client.Client, scheme *runtime.Scheme) error {
 	ingressName := getBrokerIngressName(cr)
-	if !pointy.BoolValue(cr.Spec.Broker.GenericIngressSpec.Deploy, true) {
+	if !pointy.BoolValue(cr.Spec.Broker.Deploy, true) {
 		// We're not deploying the Ingress, so we're stopping here.
 		// However, maybe we have an Ingress to clean up?
 		ingress := &voyager.Ingress{}
-		if err := c.Get(context.TODO(), types.NamespacedName{Name: ingressName, Namespace: cr.Namespace}, ingress); err == nil {
+		if err := c.Get(ctx, types.NamespacedName{Name: ingressName, Namespace: cr.Namespace}, ingress); err == nil {
 			// Delete the ingress
-			if err := c.Delete(context.TODO(), ingress); err != nil {
+			if err := misc.DeleteOwnedResource(ctx, cr, ingress, c); err != nil {
 				return err
 			}
 		}
@@ -44,21 +45,26 @@ func ensureBrokerIngress(cr *apiv1alpha1.AstarteVoyagerIngress, parent *apiv1alp
 		// Reasonable timeouts for long PINGREQs
 		voyager.DefaultsTimeOut: `{"connect": "30s", "server": "1h", "client": "1h", "tunnel": "1h"}`,
 	}
-	if cr.Spec.Broker.GenericIngressSpec.Replicas != nil {
-		annotations[voyager.Replicas] = strconv.Itoa(int(pointy.Int32Value(cr.Spec.Broker.GenericIngressSpec.Replicas, 1)))
+	if cr.Spec.Broker.Replicas != nil {
+		annotations[voyager.Replicas] = strconv.Itoa(int(pointy.Int32Value(cr.Spec.Broker.Replicas, 1)))
 	}
-	if cr.Spec.Broker.GenericIngressSpec.NodeSelector != "" {
-		annotations[voyager.NodeSelector] = cr.Spec.Broker.GenericIngressSpec.NodeSelector
+	if len(cr.Spec.Broker.NodeSelector) > 0 {
+		// Voyager expects the Node Selector as a JSON object
+		nS, err := json.Marshal(cr.Spec.Broker.NodeSelector)
+		if err != nil {
+			return err
+		}
+		annotations[voyager.NodeSelector] = string(nS)
 	}
-	if cr.Spec.Broker.GenericIngressSpec.Type != "" {
-		annotations[voyager.LBType] = cr.Spec.Broker.GenericIngressSpec.Type
+	if cr.Spec.Broker.Type != "" {
+		annotations[voyager.LBType] = cr.Spec.Broker.Type
 	}
-	if cr.Spec.Broker.GenericIngressSpec.LoadBalancerIP != "" {
-		annotations[voyager.LoadBalancerIP] = cr.Spec.Broker.GenericIngressSpec.LoadBalancerIP
+	if cr.Spec.Broker.LoadBalancerIP != "" {
+		annotations[voyager.LoadBalancerIP] = cr.Spec.Broker.LoadBalancerIP
 	}
-	if len(cr.Spec.Broker.GenericIngressSpec.AnnotationsService) > 0 {
+	if len(cr.Spec.Broker.AnnotationsService) > 0 {
 		// Marshal into a JSON, and call it a day.
-		aS, err := json.Marshal(cr.Spec.Broker.GenericIngressSpec.AnnotationsService)
+		aS, err := json.Marshal(cr.Spec.Broker.AnnotationsService)
 		if err != nil {
 			return err
 		}
@@ -71,13 +77,13 @@ func ensureBrokerIngress(cr *apiv1alpha1.AstarteVoyagerIngress, parent *apiv1alp
 
 	// TLS first
 	// Priority in options is: Ref - Secret Name - Let's Encrypt
-	if cr.Spec.Broker.GenericIngressSpec.TLSRef != nil {
-		ingressTLS = &voyager.IngressTLS{Ref: cr.Spec.Broker.GenericIngressSpec.TLSRef, Hosts: []string{parent.Spec.VerneMQ.Host}}
-	} else if cr.Spec.Broker.GenericIngressSpec.TLSSecret != "" {
-		ingressTLS = &voyager.IngressTLS{SecretName: cr.Spec.Broker.GenericIngressSpec.TLSSecret, Hosts: []string{parent.Spec.VerneMQ.Host}}
+	if cr.Spec.Broker.TLSRef != nil {
+		ingressTLS = &voyager.IngressTLS{Ref: cr.Spec.Broker.TLSRef, Hosts: []string{parent.Spec.VerneMQ.Host}}
+	} else if cr.Spec.Broker.TLSSecret != "" {
+		ingressTLS = &voyager.IngressTLS{SecretName: cr.Spec.Broker.TLSSecret, Hosts: []string{parent.Spec.VerneMQ.Host}}
 	} else if pointy.BoolValue(cr.Spec.Letsencrypt.Use, true) {
 		// Are we bootstrapping?
-		bootstrappingLE, err := isBootstrappingLEChallenge(cr, parent, c)
+		bootstrappingLE, err := isBootstrappingLEChallenge(ctx, cr, parent, c)
 		if err != nil {
 			return err
 		}
@@ -139,7 +145,7 @@ func ensureBrokerIngress(cr *apiv1alpha1.AstarteVoyagerIngress, parent *apiv1alp
 
 	// Reconcile the Ingress
 	ingress := &voyager.Ingress{ObjectMeta: metav1.ObjectMeta{Name: ingressName, Namespace: cr.Namespace}}
-	result, err := controllerutil.CreateOrUpdate(context.TODO(), c, ingress, func() error {
+	result, err := misc.Apply(ctx, c, scheme, ingress, func() error {
 		if err := controllerutil.SetControllerReference(cr, ingress, scheme); err != nil {
 			return err
 		}
@@ -150,16 +156,16 @@ func ensureBrokerIngress(cr *apiv1alpha1.AstarteVoyagerIngress, parent *apiv1alp
 		return nil
 	})
 	if err == nil {
-		logCreateOrUpdateOperationResult(result, cr, ingress)
+		logCreateOrUpdateOperationResult(ctx, result, cr, ingress)
 	}
 
 	return nil
 }
 
-func getBrokerIngressName(cr *apiv1alpha1.AstarteVoyagerIngress) string {
+func getBrokerIngressName(cr *apiv1alpha2.AstarteVoyagerIngress) string {
 	return cr.Name + "-vernemq-ingress"
 }
 
-func isBrokerIngressReady(cr *apiv1alpha1.AstarteVoyagerIngress, c client.Client) bool {
-	return isIngressReady(getBrokerIngressName(cr), cr, c)
+func isBrokerIngressReady(ctx context.Context, cr *apiv1alpha2.AstarteVoyagerIngress, c client.Client) bool {
+	return isIngressReady(ctx, getBrokerIngressName(cr), cr, c)
 }
@@ -4,7 +4,8 @@ import (
 	"context"
 
 	voyager "github.com/astarte-platform/astarte-kubernetes-operator/external/voyager/v1beta1"
-	apiv1alpha1 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha1"
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
 	"github.com/openlyinc/pointy"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -14,7 +15,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-func ensureCertificate(cr *apiv1alpha1.AstarteVoyagerIngress, parent *apiv1alpha1.Astarte, c client.Client, scheme *runtime.Scheme) error {
+func ensureCertificate(ctx context.Context, cr *apiv1alpha2.AstarteVoyagerIngress, parent *apiv1alpha2.Astarte, c client.Client, scheme *runtime.Scheme) error {
 	reqLogger := log.WithValues("Request.Namespace", cr.Namespace, "Request.Name", cr.Name)
 	acmeSecretName := cr.Name + "-voyager-acme-account"
 	certificateName := getCertificateName(cr)
@@ -22,9 +23,9 @@ func ensureCertificate(cr *apiv1alpha1.AstarteVoyagerIngress, parent *apiv1alpha
 		// We're not using Let's Encrypt, so we're stopping here.
 		// However, maybe we have a certificate to clean up?
 		certificate := &voyager.Certificate{}
-		if err := c.Get(context.TODO(), types.NamespacedName{Name: certificateName, Namespace: cr.Namespace}, certificate); err == nil {
+		if err := c.Get(ctx, types.NamespacedName{Name: certificateName, Namespace: cr.Namespace}, certificate); err == nil {
 			// Delete the certificate
-			if err := c.Delete(context.TODO(), certificate); err != nil {
+			if err := misc.DeleteOwnedResource(ctx, cr, certificate, c); err != nil {
 				return err
 			}
 		}
@@ -36,7 +37,7 @@ func ensureCertificate(cr *apiv1alpha1.AstarteVoyagerIngress, parent *apiv1alpha
 	if pointy.BoolValue(cr.Spec.Letsencrypt.Staging, false) {
 		data["ACME_SERVER_URL"] = "https://acme-staging-v02.api.letsencrypt.org/directory"
 	}
-	if _, err := reconcileSecretString(acmeSecretName, data, cr, c, scheme); err != nil {
+	if _, err := reconcileSecretString(ctx, acmeSecretName, data, cr, c, scheme); err != nil {
 		return err
 	}
 
@@ -45,7 +46,7 @@ func ensureCertificate(cr *apiv1alpha1.AstarteVoyagerIngress, parent *apiv1alpha
 	// Let's check, and just return nil in that case. Reconciliation will happen due to the status change
 	// on the Load Balancer.
 	if (cr.Spec.Letsencrypt.ChallengeProvider.HTTP != nil || pointy.BoolValue(cr.Spec.Letsencrypt.AutoHTTPChallenge, false)) &&
-		(!isAPIIngressReady(cr, c) || !isBrokerIngressReady(cr, c)) {
+		(!isAPIIngressReady(ctx, cr, c) || !isBrokerIngressReady(ctx, cr, c)) {
 		reqLogger.Info("Skipping Certificate for now, as Ingresses are not ready yet. Will check again in next Reconciliation")
 		return nil
 	}
@@ -54,7 +55,7 @@ func ensureCertificate(cr *apiv1alpha1.AstarteVoyagerIngress, parent *apiv1alpha
 	domains := cr.Spec.Letsencrypt.Domains
 	if len(domains) == 0 {
 		// Compute the domains list based on the parent Astarte resource
-		if pointy.BoolValue(parent.Spec.Components.Dashboard.GenericClusteredResource.Deploy, true) && parent.Spec.Components.Dashboard.Host != "" &&
+		if pointy.BoolValue(parent.Spec.Components.Dashboard.Deploy, true) && parent.Spec.Components.Dashboard.Host != "" &&
 			pointy.BoolValue(parent.Spec.Components.Dashboard.SSL, true) {
 			domains = append(domains, parent.Spec.Components.Dashboard.Host)
 		}
@@ -65,7 +66,7 @@ func ensureCertificate(cr *apiv1alpha1.AstarteVoyagerIngress, parent *apiv1alpha
 	}
 
 	certificate := &voyager.Certificate{ObjectMeta: metav1.ObjectMeta{Name: certificateName, Namespace: cr.Namespace}}
-	result, err := controllerutil.CreateOrUpdate(context.TODO(), c, certificate, func() error {
+	result, err := misc.Apply(ctx, c, scheme, certificate, func() error {
 		if err := controllerutil.SetControllerReference(cr, certificate, scheme); err != nil {
 			return err
 		}
@@ -90,17 +91,17 @@ func ensureCertificate(cr *apiv1alpha1.AstarteVoyagerIngress, parent *apiv1alpha
 		return nil
 	})
 	if err == nil {
-		logCreateOrUpdateOperationResult(result, cr, certificate)
+		logCreateOrUpdateOperationResult(ctx, result, cr, certificate)
 	}
 
 	return err
 }
 
-func getCertificateName(cr *apiv1alpha1.AstarteVoyagerIngress) string {
+func getCertificateName(cr *apiv1alpha2.AstarteVoyagerIngress) string {
 	return cr.Name + "-ingress-certificate"
 }
 
-func isBootstrappingLEChallenge(cr *apiv1alpha1.AstarteVoyagerIngress, parent *apiv1alpha1.Astarte, c client.Client) (bool, error) {
+func isBootstrappingLEChallenge(ctx context.Context, cr *apiv1alpha2.AstarteVoyagerIngress, parent *apiv1alpha2.Astarte, c client.Client) (bool, error) {
 	// If we're not using Let's Encrypt, that's pretty easy
 	if !pointy.BoolValue(cr.Spec.Letsencrypt.Use, true) {
 		return false, nil
@@ -112,7 +113,7 @@ func isBootstrappingLEChallenge(cr *apiv1alpha1.AstarteVoyagerIngress, parent *a
 
 	// We might be in a bootstrapping situation. Inspect the certificate to find out if it has been issued.
 	certificate := &voyager.Certificate{}
-	err := c.Get(context.TODO(), types.NamespacedName{Name: getCertificateName(cr), Namespace: cr.Namespace}, certificate)
+	err := c.Get(ctx, types.NamespacedName{Name: getCertificateName(cr), Namespace: cr.Namespace}, certificate)
 	if err == nil {
 		// Check the certificate status
 		for _, cond := range certificate.Status.Conditions {
@@ -0,0 +1,118 @@
+package astartevoyageringress
+
+import (
+	"context"
+
+	voyager "github.com/astarte-platform/astarte-kubernetes-operator/external/voyager/v1beta1"
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	"github.com/openlyinc/pointy"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// computeStatus observes the Voyager Ingresses and Certificate owned by cr, and returns its status
+func computeStatus(ctx context.Context, cr *apiv1alpha2.AstarteVoyagerIngress, parent *apiv1alpha2.Astarte, c client.Client) (apiv1alpha2.AstarteVoyagerIngressStatus, error) {
+	status := apiv1alpha2.AstarteVoyagerIngressStatus{}
+	var err error
+
+	if status.API, err = getLoadBalancerStatus(ctx, getAPIIngressName(cr), pointy.BoolValue(cr.Spec.API.Deploy, true), cr, c); err != nil {
+		return status, err
+	}
+	if status.Broker, err = getLoadBalancerStatus(ctx, getBrokerIngressName(cr), pointy.BoolValue(cr.Spec.Broker.Deploy, true), cr, c); err != nil {
+		return status, err
+	}
+	if status.Certificate, err = getCertificateStatus(ctx, cr, parent, c); err != nil {
+		return status, err
+	}
+
+	return status, nil
+}
+
+func getLoadBalancerStatus(ctx context.Context, ingressName string, deployed bool, cr *apiv1alpha2.AstarteVoyagerIngress, c client.Client) (apiv1alpha2.AstarteVoyagerIngressLoadBalancerStatus, error) {
+	status := apiv1alpha2.AstarteVoyagerIngressLoadBalancerStatus{Deployed: deployed}
+	if !deployed {
+		return status, nil
+	}
+
+	ingress := &voyager.Ingress{}
+	if err := c.Get(ctx, types.NamespacedName{Name: ingressName, Namespace: cr.Namespace}, ingress); err != nil {
+		if errors.IsNotFound(err) {
+			return status, nil
+		}
+		return status, err
+	}
+
+	for _, lbIngress := range ingress.Status.LoadBalancer.Ingress {
+		if lbIngress.IP != "" {
+			status.Addresses = append(status.Addresses, lbIngress.IP)
+		} else if lbIngress.Hostname != "" {
+			status.Addresses = append(status.Addresses, lbIngress.Hostname)
+		}
+	}
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host != "" && !containsString(status.Hosts, rule.Host) {
+			status.Hosts = append(status.Hosts, rule.Host)
+		}
+	}
+	status.Ready = isIngressReady(ctx, ingressName, cr, c)
+
+	return status, nil
+}
+
+func getCertificateStatus(ctx context.Context, cr *apiv1alpha2.AstarteVoyagerIngress, parent *apiv1alpha2.Astarte, c client.Client) (apiv1alpha2.AstarteVoyagerIngressCertificateStatus, error) {
+	status := apiv1alpha2.AstarteVoyagerIngressCertificateStatus{State: apiv1alpha2.CertificateStateNotRequested}
+	if !pointy.BoolValue(cr.Spec.Letsencrypt.Use, true) {
+		return status, nil
+	}
+
+	bootstrapping, err := isBootstrappingLEChallenge(ctx, cr, parent, c)
+	if err != nil {
+		return status, err
+	}
+	status.BootstrappingHTTPChallenge = bootstrapping
+	status.State = apiv1alpha2.CertificateStatePending
+
+	certificate := &voyager.Certificate{}
+	if err := c.Get(ctx, types.NamespacedName{Name: getCertificateName(cr), Namespace: cr.Namespace}, certificate); err != nil {
+		if errors.IsNotFound(err) {
+			return status, nil
+		}
+		return status, err
+	}
+
+	status.Domains = certificate.Spec.Domains
+	if certificate.Status.LastIssuedCertificate != nil {
+		notAfter := certificate.Status.LastIssuedCertificate.NotAfter
+		status.NotAfter = &notAfter
+	}
+	// The most recent Condition reflects the current state of the Certificate
+	var latest *voyager.CertificateCondition
+	for i, cond := range certificate.Status.Conditions {
+		if latest == nil || !cond.LastUpdateTime.Before(&latest.LastUpdateTime) {
+			latest = &certificate.Status.Conditions[i]
+		}
+	}
+	if latest != nil {
+		switch latest.Type {
+		case voyager.CertificateIssued:
+			status.State = apiv1alpha2.CertificateStateIssued
+		case voyager.CertificateFailed:
+			status.State = apiv1alpha2.CertificateStateFailed
+		case voyager.CertificateRateLimited:
+			status.State = apiv1alpha2.CertificateStateRateLimited
+		}
+		status.Message = latest.Message
+	}
+
+	return status, nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 
 	voyager "github.com/astarte-platform/astarte-kubernetes-operator/external/voyager/v1beta1"
-	apiv1alpha1 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha1"
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
 	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/misc"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -13,9 +13,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-func isIngressReady(ingressName string, cr *apiv1alpha1.AstarteVoyagerIngress, c client.Client) bool {
+func isIngressReady(ctx context.Context, ingressName string, cr *apiv1alpha2.AstarteVoyagerIngress, c client.Client) bool {
 	ingress := &voyager.Ingress{}
-	if err := c.Get(context.TODO(), types.NamespacedName{Name: ingressName, Namespace: cr.Namespace}, ingress); err != nil {
+	if err := c.Get(ctx, types.NamespacedName{Name: ingressName, Namespace: cr.Namespace}, ingress); err != nil {
 		// Don't stress it too much.
 		return false
 	}
@@ -42,18 +42,18 @@ func isIngressReady(ingressName string, cr *apiv1alpha1.AstarteVoyagerIngress, c
 	return false
 }
 
-func reconcileConfigMap(objName string, data map[string]string, cr *apiv1alpha1.AstarteVoyagerIngress, c client.Client, scheme *runtime.Scheme) (controllerutil.OperationResult, error) {
-	return misc.ReconcileConfigMap(objName, data, cr, c, scheme, log)
+func reconcileConfigMap(ctx context.Context, objName string, data map[string]string, cr *apiv1alpha2.AstarteVoyagerIngress, c client.Client, scheme *runtime.Scheme) (controllerutil.OperationResult, error) {
+	return misc.ReconcileConfigMap(ctx, objName, data, cr, c, scheme, log)
 }
 
-func reconcileSecret(objName string, data map[string][]byte, cr *apiv1alpha1.AstarteVoyagerIngress, c client.Client, scheme *runtime.Scheme) (controllerutil.OperationResult, error) {
-	return misc.ReconcileSecret(objName, data, cr, c, scheme, log)
+func reconcileSecret(ctx context.Context, objName string, data map[string][]byte, cr *apiv1alpha2.AstarteVoyagerIngress, c client.Client, scheme *runtime.Scheme) (controllerutil.OperationResult, error) {
+	return misc.ReconcileSecret(ctx, objName, data, cr, c, scheme, log)
 }
 
-func reconcileSecretString(objName string, data map[string]string, cr *apiv1alpha1.AstarteVoyagerIngress, c client.Client, scheme *runtime.Scheme) (controllerutil.OperationResult, error) {
-	return misc.ReconcileSecretString(objName, data, cr, c, scheme, log)
+func reconcileSecretString(ctx context.Context, objName string, data map[string]string, cr *apiv1alpha2.AstarteVoyagerIngress, c client.Client, scheme *runtime.Scheme) (controllerutil.OperationResult, error) {
+	return misc.ReconcileSecretString(ctx, objName, data, cr, c, scheme, log)
 }
 
-func logCreateOrUpdateOperationResult(result controllerutil.OperationResult, cr *apiv1alpha1.AstarteVoyagerIngress, obj metav1.Object) {
-	misc.LogCreateOrUpdateOperationResult(log, result, cr, obj)
+func logCreateOrUpdateOperationResult(ctx context.Context, result controllerutil.OperationResult, cr *apiv1alpha2.AstarteVoyagerIngress, obj metav1.Object) {
+	misc.LogCreateOrUpdateOperationResult(ctx, log, result, cr, obj)
 }
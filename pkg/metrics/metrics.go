@@ -0,0 +1,220 @@
+// Package metrics defines the Operator's own metrics, which are registered with the controller-runtime registry and
+// served by the Manager together with the default Controller metrics
+package metrics
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const metricsNamespace = "astarte_operator"
+
+var (
+	componentReconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "component_reconcile_duration_seconds",
+		Help:      "Time spent reconciling each Component of an Astarte Resource",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+	}, []string{"namespace", "name", "component"})
+
+	componentReconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "component_reconcile_errors_total",
+		Help:      "Failed reconciliations of each Component of an Astarte Resource, by error class",
+	}, []string{"namespace", "name", "component", "class"})
+
+	resourceOperations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "resource_operations_total",
+		Help:      "Objects reconciled on behalf of a Resource, by kind and result (created, updated or unchanged)",
+	}, []string{"namespace", "name", "kind", "result"})
+
+	upgradeStepDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "upgrade_step_duration_seconds",
+		Help:      "Time spent in each step of an Astarte upgrade, by outcome (succeeded or failed)",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 13),
+	}, []string{"namespace", "name", "step", "outcome"})
+
+	astarteReconciliationPhase = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "astarte_reconciliation_phase",
+		Help:      "Reconciliation Phase of an Astarte Resource: 1 for the current phase, 0 for all others",
+	}, []string{"namespace", "name", "phase"})
+
+	astarteHealth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "astarte_health",
+		Help:      "Health of an Astarte Resource: 1 for the current health, 0 for all others",
+	}, []string{"namespace", "name", "health"})
+
+	voyagerIngressCertificateIssued = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "voyager_ingress_certificate_issued",
+		Help:      "Whether the Let's Encrypt Certificate of an AstarteVoyagerIngress has been issued",
+	}, []string{"namespace", "name"})
+)
+
+var reconciliationPhases = []apiv1alpha2.ReconciliationPhase{
+	apiv1alpha2.ReconciliationPhaseUnknown,
+	apiv1alpha2.ReconciliationPhaseReconciling,
+	apiv1alpha2.ReconciliationPhaseUpgrading,
+	apiv1alpha2.ReconciliationPhaseReconciled,
+	apiv1alpha2.ReconciliationPhaseFailed,
+}
+
+var healthValues = []string{"green", "yellow", "red"}
+
+// deletableVec is implemented by all metric vectors
+type deletableVec interface {
+	DeleteLabelValues(lvs ...string) bool
+}
+
+// trackedSeries identifies a series of a metric vector, whose label values are joined by a NUL byte
+type trackedSeries struct {
+	vec         deletableVec
+	labelValues string
+}
+
+// resourceKey identifies a Resource. Its kind tells apart Astarte and AstarteVoyagerIngress Resources which share
+// their name.
+type resourceKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// Series whose labels include values which can't be enumerated, such as Components, kinds or steps, are tracked per
+// Resource, so that they can be deleted together with it
+var (
+	trackedSeriesLock       sync.Mutex
+	trackedSeriesByResource = map[resourceKey]map[trackedSeries]struct{}{}
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		componentReconcileDuration,
+		componentReconcileErrors,
+		resourceOperations,
+		upgradeStepDuration,
+		astarteReconciliationPhase,
+		astarteHealth,
+		voyagerIngressCertificateIssued,
+	)
+}
+
+// ObserveComponentReconcile records a reconciliation of component which lasted duration. errorClass is empty when
+// the reconciliation succeeded.
+func ObserveComponentReconcile(cr metav1.Object, component string, duration time.Duration, errorClass string) {
+	trackSeries(componentReconcileDuration, cr, component)
+	componentReconcileDuration.WithLabelValues(cr.GetNamespace(), cr.GetName(), component).Observe(duration.Seconds())
+	if errorClass != "" {
+		trackSeries(componentReconcileErrors, cr, component, errorClass)
+		componentReconcileErrors.WithLabelValues(cr.GetNamespace(), cr.GetName(), component, errorClass).Inc()
+	}
+}
+
+// RecordResourceOperation records the result of reconciling an object of kind on behalf of cr
+func RecordResourceOperation(cr metav1.Object, kind, result string) {
+	trackSeries(resourceOperations, cr, kind, result)
+	resourceOperations.WithLabelValues(cr.GetNamespace(), cr.GetName(), kind, result).Inc()
+}
+
+// ObserveUpgradeStep records an upgrade step of cr which lasted duration, and whether it succeeded
+func ObserveUpgradeStep(cr metav1.Object, step string, duration time.Duration, succeeded bool) {
+	outcome := "succeeded"
+	if !succeeded {
+		outcome = "failed"
+	}
+	trackSeries(upgradeStepDuration, cr, step, outcome)
+	upgradeStepDuration.WithLabelValues(cr.GetNamespace(), cr.GetName(), step, outcome).Observe(duration.Seconds())
+}
+
+// SetAstarteStatus exposes the Reconciliation Phase and Health of cr
+func SetAstarteStatus(cr *apiv1alpha2.Astarte) {
+	for _, phase := range reconciliationPhases {
+		astarteReconciliationPhase.WithLabelValues(cr.Namespace, cr.Name, getPhaseLabel(phase)).
+			Set(boolToFloat(phase == cr.Status.ReconciliationPhase))
+	}
+	for _, health := range healthValues {
+		astarteHealth.WithLabelValues(cr.Namespace, cr.Name, health).Set(boolToFloat(health == cr.Status.Health))
+	}
+}
+
+// DeleteAstarteStatus stops exposing the status of an Astarte Resource which no longer exists, together with all
+// metrics about its reconciliations
+func DeleteAstarteStatus(namespace, name string) {
+	deleteTrackedSeries(getResourceKey(&apiv1alpha2.Astarte{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}))
+	for _, phase := range reconciliationPhases {
+		astarteReconciliationPhase.DeleteLabelValues(namespace, name, getPhaseLabel(phase))
+	}
+	for _, health := range healthValues {
+		astarteHealth.DeleteLabelValues(namespace, name, health)
+	}
+}
+
+// SetVoyagerIngressCertificateState exposes whether the Certificate of cr has been issued
+func SetVoyagerIngressCertificateState(cr *apiv1alpha2.AstarteVoyagerIngress) {
+	voyagerIngressCertificateIssued.WithLabelValues(cr.Namespace, cr.Name).
+		Set(boolToFloat(cr.Status.Certificate.State == apiv1alpha2.CertificateStateIssued))
+}
+
+// DeleteVoyagerIngressCertificateState stops exposing the Certificate state of an AstarteVoyagerIngress which no
+// longer exists, together with all metrics about its reconciliations
+func DeleteVoyagerIngressCertificateState(namespace, name string) {
+	deleteTrackedSeries(getResourceKey(&apiv1alpha2.AstarteVoyagerIngress{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}))
+	voyagerIngressCertificateIssued.DeleteLabelValues(namespace, name)
+}
+
+// trackSeries records that the series of vec labelled with the namespace and name of cr, followed by labelValues, is
+// exposed
+func trackSeries(vec deletableVec, cr metav1.Object, labelValues ...string) {
+	trackedSeriesLock.Lock()
+	defer trackedSeriesLock.Unlock()
+
+	key := getResourceKey(cr)
+	if trackedSeriesByResource[key] == nil {
+		trackedSeriesByResource[key] = map[trackedSeries]struct{}{}
+	}
+	trackedSeriesByResource[key][trackedSeries{vec: vec, labelValues: strings.Join(labelValues, "\x00")}] = struct{}{}
+}
+
+// deleteTrackedSeries deletes all series tracked for the Resource identified by key
+func deleteTrackedSeries(key resourceKey) {
+	trackedSeriesLock.Lock()
+	defer trackedSeriesLock.Unlock()
+
+	for series := range trackedSeriesByResource[key] {
+		series.vec.DeleteLabelValues(append([]string{key.namespace, key.name}, strings.Split(series.labelValues, "\x00")...)...)
+	}
+	delete(trackedSeriesByResource, key)
+}
+
+func getResourceKey(cr metav1.Object) resourceKey {
+	return resourceKey{
+		kind:      reflect.Indirect(reflect.ValueOf(cr)).Type().Name(),
+		namespace: cr.GetNamespace(),
+		name:      cr.GetName(),
+	}
+}
+
+func getPhaseLabel(phase apiv1alpha2.ReconciliationPhase) string {
+	if phase == apiv1alpha2.ReconciliationPhaseUnknown {
+		return "Unknown"
+	}
+	return string(phase)
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
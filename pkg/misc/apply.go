@@ -0,0 +1,67 @@
+package misc
+
+import (
+	"context"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// FieldManager is the manager owning the fields applied by the Operator
+const FieldManager = "astarte-operator"
+
+// Apply reconciles obj through server-side apply. f renders the desired state into obj, which should hold nothing
+// but what the Operator wants to own: fields set by other controllers or tools are left alone, unless the Operator
+// sets them too. Existing objects marked as unmanaged are left untouched.
+func Apply(ctx context.Context, c client.Client, scheme *runtime.Scheme, obj runtime.Object, f controllerutil.MutateFn) (controllerutil.OperationResult, error) {
+	key, err := client.ObjectKeyFromObject(obj)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	existing := obj.DeepCopyObject()
+	exists := true
+	if err := c.Get(ctx, key, existing); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return controllerutil.OperationResultNone, err
+		}
+		exists = false
+	}
+	existingAccessor, err := meta.Accessor(existing)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	if exists && IsUnmanaged(existingAccessor) {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	if err := f(); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	// Applied objects must carry their Kind, which the Operator doesn't set when building them
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+
+	if err := c.Patch(ctx, obj, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	if !exists {
+		return controllerutil.OperationResultCreated, nil
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	if accessor.GetResourceVersion() != existingAccessor.GetResourceVersion() {
+		return controllerutil.OperationResultUpdated, nil
+	}
+	return controllerutil.OperationResultNone, nil
+}
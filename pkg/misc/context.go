@@ -0,0 +1,142 @@
+package misc
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// Timeouts bound how long the Operator waits for the cluster
+type Timeouts struct {
+	// Reconcile bounds a whole reconciliation of a Resource
+	Reconcile time.Duration
+	// Upgrade is granted to reconciliations which upgrade Astarte, on top of Reconcile
+	Upgrade time.Duration
+	// Operation bounds every single call to the API Server
+	Operation time.Duration
+}
+
+var timeouts = Timeouts{
+	Reconcile: 10 * time.Minute,
+	Upgrade:   time.Hour,
+	Operation: 30 * time.Second,
+}
+
+// TimeoutsFlagSet returns the flags configuring the Operator's Timeouts. It must be parsed before the Controllers
+// are added to the Manager.
+func TimeoutsFlagSet() *pflag.FlagSet {
+	flagSet := pflag.NewFlagSet("timeouts", pflag.ExitOnError)
+	flagSet.DurationVar(&timeouts.Reconcile, "reconcile-timeout", timeouts.Reconcile,
+		"Maximum duration of a reconciliation")
+	flagSet.DurationVar(&timeouts.Upgrade, "upgrade-timeout", timeouts.Upgrade,
+		"Additional duration granted to reconciliations upgrading Astarte")
+	flagSet.DurationVar(&timeouts.Operation, "api-timeout", timeouts.Operation,
+		"Maximum duration of a single call to the API Server")
+	return flagSet
+}
+
+// GetTimeouts returns the Operator's Timeouts
+func GetTimeouts() Timeouts {
+	return timeouts
+}
+
+// NewManagerContext returns a context which is cancelled as soon as mgr stops, e.g. when the Operator is terminated,
+// so that in-flight reconciliations are aborted rather than holding back the shutdown
+func NewManagerContext(mgr manager.Manager) (context.Context, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := mgr.Add(manager.RunnableFunc(func(stop <-chan struct{}) error {
+		<-stop
+		cancel()
+		return nil
+	})); err != nil {
+		cancel()
+		return nil, err
+	}
+	return ctx, nil
+}
+
+// NewTimeoutClient returns a client bounding every call to the API Server to timeout, within the deadline of the
+// context it's given
+func NewTimeoutClient(c client.Client, timeout time.Duration) client.Client {
+	return &timeoutClient{Client: c, timeout: timeout}
+}
+
+type timeoutClient struct {
+	client.Client
+	timeout time.Duration
+}
+
+// Get implements client.Reader
+func (t *timeoutClient) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.Client.Get(ctx, key, obj)
+}
+
+// List implements client.Reader
+func (t *timeoutClient) List(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.Client.List(ctx, list, opts...)
+}
+
+// Create implements client.Writer
+func (t *timeoutClient) Create(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.Client.Create(ctx, obj, opts...)
+}
+
+// Delete implements client.Writer
+func (t *timeoutClient) Delete(ctx context.Context, obj runtime.Object, opts ...client.DeleteOption) error {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.Client.Delete(ctx, obj, opts...)
+}
+
+// Update implements client.Writer
+func (t *timeoutClient) Update(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.Client.Update(ctx, obj, opts...)
+}
+
+// Patch implements client.Writer
+func (t *timeoutClient) Patch(ctx context.Context, obj runtime.Object, patch client.Patch, opts ...client.PatchOption) error {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.Client.Patch(ctx, obj, patch, opts...)
+}
+
+// DeleteAllOf implements client.Writer
+func (t *timeoutClient) DeleteAllOf(ctx context.Context, obj runtime.Object, opts ...client.DeleteAllOfOption) error {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+// Status implements client.StatusClient
+func (t *timeoutClient) Status() client.StatusWriter {
+	return &timeoutStatusWriter{StatusWriter: t.Client.Status(), timeout: t.timeout}
+}
+
+type timeoutStatusWriter struct {
+	client.StatusWriter
+	timeout time.Duration
+}
+
+func (t *timeoutStatusWriter) Update(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.StatusWriter.Update(ctx, obj, opts...)
+}
+
+func (t *timeoutStatusWriter) Patch(ctx context.Context, obj runtime.Object, patch client.Patch, opts ...client.PatchOption) error {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.StatusWriter.Patch(ctx, obj, patch, opts...)
+}
@@ -0,0 +1,86 @@
+package misc
+
+import (
+	"context"
+	"reflect"
+
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// Reasons of the Events emitted on Astarte Resources
+const (
+	// EventReasonCreated means a resource owned by the Astarte Resource was created
+	EventReasonCreated = "Created"
+	// EventReasonUpdated means a resource owned by the Astarte Resource was updated
+	EventReasonUpdated = "Updated"
+	// EventReasonDeleted means a resource owned by the Astarte Resource was deleted, as it's no longer needed
+	EventReasonDeleted = "Deleted"
+	// EventReasonReconcileFailed means the Astarte Resource failed to reconcile
+	EventReasonReconcileFailed = "ReconcileFailed"
+	// EventReasonHousekeepingKeyGenerated means a new Housekeeping key pair was generated
+	EventReasonHousekeepingKeyGenerated = "HousekeepingKeyGenerated"
+	// EventReasonCAJobCreated means the Job storing CFSSL's CA in a Secret was created
+	EventReasonCAJobCreated = "CAJobCreated"
+	// EventReasonUpgradeStarted means an upgrade of the Astarte Resource started
+	EventReasonUpgradeStarted = "UpgradeStarted"
+	// EventReasonUpgradeStepCompleted means a step of an upgrade completed successfully
+	EventReasonUpgradeStepCompleted = "UpgradeStepCompleted"
+	// EventReasonUpgradeSucceeded means an upgrade of the Astarte Resource completed successfully
+	EventReasonUpgradeSucceeded = "UpgradeSucceeded"
+	// EventReasonUpgradeFailed means an upgrade of the Astarte Resource failed
+	EventReasonUpgradeFailed = "UpgradeFailed"
+	// EventReasonFinalized means the Astarte Resource was finalized, and the resources it left behind cleaned up
+	EventReasonFinalized = "Finalized"
+	// EventReasonPersistentVolumeClaimDeleted means a PersistentVolumeClaim was deleted while finalizing the Astarte Resource
+	EventReasonPersistentVolumeClaimDeleted = "PersistentVolumeClaimDeleted"
+)
+
+type eventRecorderKey struct{}
+
+// WithEventRecorder returns a copy of ctx carrying recorder, which the Events recorded with it are emitted through.
+// Reconcilers set it on the context of each reconciliation, so that everything they call into can report Events.
+func WithEventRecorder(ctx context.Context, recorder record.EventRecorder) context.Context {
+	return context.WithValue(ctx, eventRecorderKey{}, recorder)
+}
+
+// getEventRecorder returns the EventRecorder carried by ctx. Events recorded with a context carrying none are
+// discarded.
+func getEventRecorder(ctx context.Context) record.EventRecorder {
+	if recorder, ok := ctx.Value(eventRecorderKey{}).(record.EventRecorder); ok {
+		return recorder
+	}
+	return &record.FakeRecorder{}
+}
+
+// RecordEvent emits a Normal Event on cr
+func RecordEvent(ctx context.Context, cr runtime.Object, reason, messageFmt string, args ...interface{}) {
+	getEventRecorder(ctx).Eventf(cr, v1.EventTypeNormal, reason, messageFmt, args...)
+}
+
+// RecordWarningEvent emits a Warning Event on cr
+func RecordWarningEvent(ctx context.Context, cr runtime.Object, reason, messageFmt string, args ...interface{}) {
+	getEventRecorder(ctx).Eventf(cr, v1.EventTypeWarning, reason, messageFmt, args...)
+}
+
+// RecordDeletionEvent emits an Event on cr, reporting that obj was deleted as it's no longer needed
+func RecordDeletionEvent(ctx context.Context, cr runtime.Object, obj metav1.Object) {
+	if accessor, err := meta.Accessor(cr); err == nil && IsPlanning(accessor) {
+		return
+	}
+	RecordEvent(ctx, cr, EventReasonDeleted, "Deleted %s %s, as it is no longer needed", getObjectKind(obj), obj.GetName())
+}
+
+// IsPlanning returns whether changes to cr are only being planned, rather than applied
+func IsPlanning(cr metav1.Object) bool {
+	return cr.GetAnnotations()[apiv1alpha2.PlanAnnotation] == "true"
+}
+
+// getObjectKind returns the Kind of a typed object, whose TypeMeta is usually empty when it's built by the Operator
+func getObjectKind(obj metav1.Object) string {
+	return reflect.Indirect(reflect.ValueOf(obj)).Type().Name()
+}
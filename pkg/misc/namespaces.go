@@ -0,0 +1,126 @@
+package misc
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/spf13/pflag"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var namespacesLog = logf.Log.WithName("namespaces")
+
+var watchNamespaceSelector string
+
+// NamespacesFlagSet returns the flags choosing the namespaces the Operator watches. It must be parsed before the
+// Controllers are added to the Manager.
+func NamespacesFlagSet() *pflag.FlagSet {
+	flagSet := pflag.NewFlagSet("namespaces", pflag.ExitOnError)
+	flagSet.StringVar(&watchNamespaceSelector, "watch-namespace-selector", "",
+		"Watch all namespaces matching this label selector, including those labelled or created later. The whole "+
+			"cluster is watched, and requires cluster-wide RBAC. Can't be used together with WATCH_NAMESPACE")
+	return flagSet
+}
+
+// GetWatchNamespaceSelector returns the label selector the watched namespaces must match. It selects every
+// namespace when no selector was given.
+func GetWatchNamespaceSelector() (labels.Selector, error) {
+	return labels.Parse(watchNamespaceSelector)
+}
+
+// IsWatchNamespaceSelectorSet returns whether the watched namespaces are chosen through a label selector
+func IsWatchNamespaceSelectorSet() bool {
+	return watchNamespaceSelector != ""
+}
+
+// NewNamespaceSelectorPredicate returns a predicate dropping the events of objects whose namespace doesn't match the
+// watch namespace selector. Namespaces are read through c, and are looked up again on every event so that label
+// changes are honored right away. It lets everything through when no selector was given.
+func NewNamespaceSelectorPredicate(ctx context.Context, c client.Client) (predicate.Predicate, error) {
+	selector, err := GetWatchNamespaceSelector()
+	if err != nil {
+		return nil, err
+	}
+	if selector.Empty() {
+		return predicate.Funcs{}, nil
+	}
+
+	matches := func(obj metav1.Object) bool {
+		return namespaceMatches(ctx, c, selector, obj.GetNamespace())
+	}
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return matches(e.Meta) },
+		UpdateFunc:  func(e event.UpdateEvent) bool { return matches(e.MetaNew) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return matches(e.Meta) },
+		GenericFunc: func(e event.GenericEvent) bool { return matches(e.Meta) },
+	}, nil
+}
+
+// WatchSelectedNamespaces makes ctrl requeue all objects of list's Kind in a namespace whenever it's created or
+// relabelled to match the watch namespace selector, so that they are reconciled without waiting for them to change.
+// Objects are listed through cl. It does nothing when no selector was given.
+func WatchSelectedNamespaces(ctx context.Context, ctrl controller.Controller, cl client.Client, list runtime.Object) error {
+	selector, err := GetWatchNamespaceSelector()
+	if err != nil {
+		return err
+	}
+	if selector.Empty() {
+		return nil
+	}
+
+	mapper := handler.ToRequestsFunc(func(o handler.MapObject) []reconcile.Request {
+		if !selector.Matches(labels.Set(o.Meta.GetLabels())) {
+			return nil
+		}
+
+		objects := list.DeepCopyObject()
+		if err := cl.List(ctx, objects, client.InNamespace(o.Meta.GetName())); err != nil {
+			namespacesLog.Error(err, "Could not list objects in namespace", "Namespace", o.Meta.GetName())
+			return nil
+		}
+		items, err := meta.ExtractList(objects)
+		if err != nil {
+			namespacesLog.Error(err, "Could not list objects in namespace", "Namespace", o.Meta.GetName())
+			return nil
+		}
+
+		requests := []reconcile.Request{}
+		for _, item := range items {
+			if m, err := meta.Accessor(item); err == nil {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: m.GetName(), Namespace: m.GetNamespace()},
+				})
+			}
+		}
+		return requests
+	})
+	labelsChanged := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !reflect.DeepEqual(e.MetaOld.GetLabels(), e.MetaNew.GetLabels())
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool { return false },
+	}
+	return ctrl.Watch(&source.Kind{Type: &v1.Namespace{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: mapper}, labelsChanged)
+}
+
+func namespaceMatches(ctx context.Context, c client.Client, selector labels.Selector, name string) bool {
+	namespace := &v1.Namespace{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name}, namespace); err != nil {
+		namespacesLog.Error(err, "Could not get namespace, dropping event", "Namespace", name)
+		return false
+	}
+	return selector.Matches(labels.Set(namespace.Labels))
+}
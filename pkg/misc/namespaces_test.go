@@ -0,0 +1,47 @@
+package misc
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestNamespaceSelectorPredicate(t *testing.T) {
+	defer func(selector string) { watchNamespaceSelector = selector }(watchNamespaceSelector)
+
+	c := fake.NewFakeClient(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "astarte", Labels: map[string]string{"astarte-platform.org/managed": "true"}}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+	)
+
+	testCases := []struct {
+		name      string
+		selector  string
+		namespace string
+		expected  bool
+	}{
+		{"no selector", "", "other", true},
+		{"matching namespace", "astarte-platform.org/managed=true", "astarte", true},
+		{"other namespace", "astarte-platform.org/managed=true", "other", false},
+		{"missing namespace", "astarte-platform.org/managed=true", "deleted", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			watchNamespaceSelector = tc.selector
+			p, err := NewNamespaceSelectorPredicate(context.Background(), c)
+			if err != nil {
+				t.Fatalf("Could not create predicate: %v", err)
+			}
+
+			secret := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "example-astarte-cookie", Namespace: tc.namespace}}
+			if got := p.Create(event.CreateEvent{Meta: secret, Object: secret}); got != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package misc
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// IgnoreStatusUpdatesPredicate drops updates which only touched the status of an object, or its bookkeeping metadata.
+// Changes to the Spec, data, labels, annotations, owners and deletion timestamp still go through.
+var IgnoreStatusUpdatesPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		if e.ObjectOld == nil || e.ObjectNew == nil {
+			return true
+		}
+		oldContent, err := GetComparableContent(e.ObjectOld)
+		if err != nil {
+			return true
+		}
+		newContent, err := GetComparableContent(e.ObjectNew)
+		if err != nil {
+			return true
+		}
+		return !reflect.DeepEqual(oldContent, newContent)
+	},
+}
+
+// GetComparableContent returns the content of obj stripped of everything which changes without the object being
+// edited, such as its status
+func GetComparableContent(obj runtime.Object) (map[string]interface{}, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	// Typed objects don't always carry their Kind
+	for _, field := range []string{"apiVersion", "kind", "status"} {
+		delete(content, field)
+	}
+	if metadata, ok := content["metadata"].(map[string]interface{}); ok {
+		for _, field := range []string{"resourceVersion", "generation", "managedFields"} {
+			delete(metadata, field)
+		}
+	}
+	return content, nil
+}
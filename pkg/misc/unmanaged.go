@@ -0,0 +1,47 @@
+package misc
+
+import (
+	"context"
+
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// IsUnmanaged returns whether obj was opted out of reconciliation through the UnmanagedAnnotation
+func IsUnmanaged(obj metav1.Object) bool {
+	return obj.GetAnnotations()[apiv1alpha2.UnmanagedAnnotation] == "true"
+}
+
+// CreateOrUpdate works like controllerutil.CreateOrUpdate, but leaves existing objects marked as unmanaged untouched.
+// Objects which don't exist yet are always created.
+func CreateOrUpdate(ctx context.Context, c client.Client, obj runtime.Object, f controllerutil.MutateFn) (controllerutil.OperationResult, error) {
+	return controllerutil.CreateOrUpdate(ctx, c, obj, func() error {
+		// At this point obj holds what is currently in the cluster, if anything
+		if accessor, err := meta.Accessor(obj); err == nil && IsUnmanaged(accessor) {
+			return nil
+		}
+		return f()
+	})
+}
+
+// DeleteOwnedResource deletes obj, which is no longer needed by cr, and emits an Event about it. Objects marked as
+// unmanaged are left in place.
+func DeleteOwnedResource(ctx context.Context, cr runtime.Object, obj runtime.Object, c client.Client) error {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	if IsUnmanaged(accessor) {
+		return nil
+	}
+
+	if err := c.Delete(ctx, obj); err != nil {
+		return err
+	}
+	RecordDeletionEvent(ctx, cr, accessor)
+	return nil
+}
@@ -4,7 +4,8 @@ import (
 	"context"
 	"fmt"
 
-	apiv1alpha1 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha1"
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/metrics"
 	"github.com/go-logr/logr"
 	"github.com/openlyinc/pointy"
 	v1 "k8s.io/api/core/v1"
@@ -23,10 +24,10 @@ const (
 	RabbitMQDefaultUserCredentialsPasswordKey = "admin-password"
 )
 
-// ReconcileConfigMap creates or updates a ConfigMap through controllerutil through its data map
-func ReconcileConfigMap(objName string, data map[string]string, cr metav1.Object, c client.Client, scheme *runtime.Scheme, log logr.Logger) (controllerutil.OperationResult, error) {
+// ReconcileConfigMap creates or updates a ConfigMap through server-side apply through its data map
+func ReconcileConfigMap(ctx context.Context, objName string, data map[string]string, cr metav1.Object, c client.Client, scheme *runtime.Scheme, log logr.Logger) (controllerutil.OperationResult, error) {
 	configMap := &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: objName, Namespace: cr.GetNamespace()}}
-	result, err := controllerutil.CreateOrUpdate(context.TODO(), c, configMap, func() error {
+	result, err := Apply(ctx, c, scheme, configMap, func() error {
 		if err := controllerutil.SetControllerReference(cr, configMap, scheme); err != nil {
 			return err
 		}
@@ -39,14 +40,14 @@ func ReconcileConfigMap(objName string, data map[string]string, cr metav1.Object
 		return controllerutil.OperationResultNone, err
 	}
 
-	LogCreateOrUpdateOperationResult(log, result, cr, configMap)
+	LogCreateOrUpdateOperationResult(ctx, log, result, cr, configMap)
 	return result, err
 }
 
-// ReconcileSecret creates or updates a Secret through controllerutil through its data
-func ReconcileSecret(objName string, data map[string][]byte, cr metav1.Object, c client.Client, scheme *runtime.Scheme, log logr.Logger) (controllerutil.OperationResult, error) {
+// ReconcileSecret creates or updates a Secret through server-side apply through its data
+func ReconcileSecret(ctx context.Context, objName string, data map[string][]byte, cr metav1.Object, c client.Client, scheme *runtime.Scheme, log logr.Logger) (controllerutil.OperationResult, error) {
 	secret := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: objName, Namespace: cr.GetNamespace()}}
-	result, err := controllerutil.CreateOrUpdate(context.TODO(), c, secret, func() error {
+	result, err := Apply(ctx, c, scheme, secret, func() error {
 		if err := controllerutil.SetControllerReference(cr, secret, scheme); err != nil {
 			return err
 		}
@@ -60,14 +61,14 @@ func ReconcileSecret(objName string, data map[string][]byte, cr metav1.Object, c
 		return controllerutil.OperationResultNone, err
 	}
 
-	LogCreateOrUpdateOperationResult(log, result, cr, secret)
+	LogCreateOrUpdateOperationResult(ctx, log, result, cr, secret)
 	return result, err
 }
 
-// ReconcileSecretString creates or updates a Secret through controllerutil by using StringData
-func ReconcileSecretString(objName string, data map[string]string, cr metav1.Object, c client.Client, scheme *runtime.Scheme, log logr.Logger) (controllerutil.OperationResult, error) {
+// ReconcileSecretString creates or updates a Secret through server-side apply by using StringData
+func ReconcileSecretString(ctx context.Context, objName string, data map[string]string, cr metav1.Object, c client.Client, scheme *runtime.Scheme, log logr.Logger) (controllerutil.OperationResult, error) {
 	secret := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: objName, Namespace: cr.GetNamespace()}}
-	result, err := controllerutil.CreateOrUpdate(context.TODO(), c, secret, func() error {
+	result, err := Apply(ctx, c, scheme, secret, func() error {
 		if err := controllerutil.SetControllerReference(cr, secret, scheme); err != nil {
 			return err
 		}
@@ -81,18 +82,31 @@ func ReconcileSecretString(objName string, data map[string]string, cr metav1.Obj
 		return controllerutil.OperationResultNone, err
 	}
 
-	LogCreateOrUpdateOperationResult(log, result, cr, secret)
+	LogCreateOrUpdateOperationResult(ctx, log, result, cr, secret)
 	return result, err
 }
 
-// LogCreateOrUpdateOperationResult logs conveniently a controllerutil Operation
-func LogCreateOrUpdateOperationResult(log logr.Logger, result controllerutil.OperationResult, cr metav1.Object, obj metav1.Object) {
+// LogCreateOrUpdateOperationResult logs conveniently a controllerutil Operation. Resources being created or updated
+// are also reported as Events on cr.
+func LogCreateOrUpdateOperationResult(ctx context.Context, log logr.Logger, result controllerutil.OperationResult, cr metav1.Object, obj metav1.Object) {
 	reqLogger := log.WithValues("Request.Namespace", cr.GetNamespace(), "Request.Name", cr.GetName())
+	crObject, isRuntimeObject := cr.(runtime.Object)
+	// Planned changes aren't actually applied, so they deserve no Event
+	isRuntimeObject = isRuntimeObject && !IsPlanning(cr)
+	if !IsPlanning(cr) {
+		metrics.RecordResourceOperation(cr, getObjectKind(obj), string(result))
+	}
 	switch result {
 	case controllerutil.OperationResultCreated:
 		reqLogger.Info("Resource created", "Resource", obj.GetName())
+		if isRuntimeObject {
+			RecordEvent(ctx, crObject, EventReasonCreated, "Created %s %s", getObjectKind(obj), obj.GetName())
+		}
 	case controllerutil.OperationResultUpdated:
 		reqLogger.Info("Resource updated", "Resource", obj.GetName())
+		if isRuntimeObject {
+			RecordEvent(ctx, crObject, EventReasonUpdated, "Updated %s %s", getObjectKind(obj), obj.GetName())
+		}
 	case controllerutil.OperationResultNone:
 		// Debug level logging, we don't want to clutter
 		reqLogger.V(1).Info("Resource unchanged", "Resource", obj.GetName())
@@ -100,7 +114,7 @@ func LogCreateOrUpdateOperationResult(log logr.Logger, result controllerutil.Ope
 }
 
 // GetVerneMQBrokerURL returns the complete URL for VerneMQ (MQTT) for an Astarte resource
-func GetVerneMQBrokerURL(cr *apiv1alpha1.Astarte) string {
+func GetVerneMQBrokerURL(cr *apiv1alpha2.Astarte) string {
 	return fmt.Sprintf("mqtts://%s:%d", cr.Spec.VerneMQ.Host, pointy.Int16Value(cr.Spec.VerneMQ.Port, 8883))
 }
 
@@ -112,7 +126,7 @@ func IsResourceRequirementsExplicit(r v1.ResourceRequirements) bool {
 // GetResourcesForAstarteComponent returns the allocated resources for a given Astarte component, taking into account both the
 // directive from Components, and the directive from the individual component (if any).
 // It will compute a ResourceRequirements for the component based on said values and internal logic.
-func GetResourcesForAstarteComponent(cr *apiv1alpha1.Astarte, requestedResources v1.ResourceRequirements, component apiv1alpha1.AstarteComponent) v1.ResourceRequirements {
+func GetResourcesForAstarteComponent(cr *apiv1alpha2.Astarte, requestedResources v1.ResourceRequirements, component apiv1alpha2.AstarteComponent) v1.ResourceRequirements {
 	if IsResourceRequirementsExplicit(requestedResources) {
 		// There has been an explicit allocation, so return that
 		return requestedResources
@@ -128,34 +142,34 @@ func GetResourcesForAstarteComponent(cr *apiv1alpha1.Astarte, requestedResources
 	var memoryCoefficient float64
 	var cpuCoefficient float64
 	switch component {
-	case apiv1alpha1.AppEngineAPI:
+	case apiv1alpha2.AppEngineAPI:
 		cpuCoefficient = 0.19
 		memoryCoefficient = 0.19
-	case apiv1alpha1.DataUpdaterPlant:
+	case apiv1alpha2.DataUpdaterPlant:
 		cpuCoefficient = 0.22
 		memoryCoefficient = 0.22
-	case apiv1alpha1.Housekeeping:
+	case apiv1alpha2.Housekeeping:
 		cpuCoefficient = 0.05
 		memoryCoefficient = 0.05
-	case apiv1alpha1.HousekeepingAPI:
+	case apiv1alpha2.HousekeepingAPI:
 		cpuCoefficient = 0.05
 		memoryCoefficient = 0.05
-	case apiv1alpha1.Pairing:
+	case apiv1alpha2.Pairing:
 		cpuCoefficient = 0.07
 		memoryCoefficient = 0.07
-	case apiv1alpha1.PairingAPI:
+	case apiv1alpha2.PairingAPI:
 		cpuCoefficient = 0.14
 		memoryCoefficient = 0.14
-	case apiv1alpha1.RealmManagement:
+	case apiv1alpha2.RealmManagement:
 		cpuCoefficient = 0.07
 		memoryCoefficient = 0.07
-	case apiv1alpha1.RealmManagementAPI:
+	case apiv1alpha2.RealmManagementAPI:
 		cpuCoefficient = 0.07
 		memoryCoefficient = 0.07
-	case apiv1alpha1.TriggerEngine:
+	case apiv1alpha2.TriggerEngine:
 		cpuCoefficient = 0.08
 		memoryCoefficient = 0.08
-	case apiv1alpha1.Dashboard:
+	case apiv1alpha2.Dashboard:
 		cpuCoefficient = 0.06
 		memoryCoefficient = 0.06
 	}
@@ -182,27 +196,27 @@ func GetResourcesForAstarteComponent(cr *apiv1alpha1.Astarte, requestedResources
 }
 
 // IsAstarteComponentDeployed returns whether an Astarte component is deployed by cr
-func IsAstarteComponentDeployed(cr *apiv1alpha1.Astarte, component apiv1alpha1.AstarteComponent) bool {
+func IsAstarteComponentDeployed(cr *apiv1alpha2.Astarte, component apiv1alpha2.AstarteComponent) bool {
 	switch component {
-	case apiv1alpha1.AppEngineAPI:
-		return pointy.BoolValue(cr.Spec.Components.AppengineAPI.GenericAPISpec.GenericClusteredResource.Deploy, true)
-	case apiv1alpha1.Dashboard:
-		return pointy.BoolValue(cr.Spec.Components.Dashboard.GenericClusteredResource.Deploy, true)
-	case apiv1alpha1.DataUpdaterPlant:
-		return pointy.BoolValue(cr.Spec.Components.DataUpdaterPlant.GenericClusteredResource.Deploy, true)
-	case apiv1alpha1.Housekeeping:
+	case apiv1alpha2.AppEngineAPI:
+		return pointy.BoolValue(cr.Spec.Components.AppengineAPI.AstarteGenericClusteredResource.Deploy, true)
+	case apiv1alpha2.Dashboard:
+		return pointy.BoolValue(cr.Spec.Components.Dashboard.Deploy, true)
+	case apiv1alpha2.DataUpdaterPlant:
+		return pointy.BoolValue(cr.Spec.Components.DataUpdaterPlant.Deploy, true)
+	case apiv1alpha2.Housekeeping:
 		return pointy.BoolValue(cr.Spec.Components.Housekeeping.Backend.Deploy, true)
-	case apiv1alpha1.HousekeepingAPI:
-		return pointy.BoolValue(cr.Spec.Components.Housekeeping.API.GenericClusteredResource.Deploy, true)
-	case apiv1alpha1.Pairing:
+	case apiv1alpha2.HousekeepingAPI:
+		return pointy.BoolValue(cr.Spec.Components.Housekeeping.API.Deploy, true)
+	case apiv1alpha2.Pairing:
 		return pointy.BoolValue(cr.Spec.Components.Pairing.Backend.Deploy, true)
-	case apiv1alpha1.PairingAPI:
-		return pointy.BoolValue(cr.Spec.Components.Pairing.API.GenericClusteredResource.Deploy, true)
-	case apiv1alpha1.RealmManagement:
+	case apiv1alpha2.PairingAPI:
+		return pointy.BoolValue(cr.Spec.Components.Pairing.API.Deploy, true)
+	case apiv1alpha2.RealmManagement:
 		return pointy.BoolValue(cr.Spec.Components.RealmManagement.Backend.Deploy, true)
-	case apiv1alpha1.RealmManagementAPI:
-		return pointy.BoolValue(cr.Spec.Components.RealmManagement.API.GenericClusteredResource.Deploy, true)
-	case apiv1alpha1.TriggerEngine:
+	case apiv1alpha2.RealmManagementAPI:
+		return pointy.BoolValue(cr.Spec.Components.RealmManagement.API.Deploy, true)
+	case apiv1alpha2.TriggerEngine:
 		return pointy.BoolValue(cr.Spec.Components.TriggerEngine.Deploy, true)
 	}
 
@@ -211,7 +225,7 @@ func IsAstarteComponentDeployed(cr *apiv1alpha1.Astarte, component apiv1alpha1.A
 }
 
 // GetRabbitMQHostnameAndPort returns the Cluster-accessible Hostname and AMQP port for RabbitMQ
-func GetRabbitMQHostnameAndPort(cr *apiv1alpha1.Astarte) (string, int16) {
+func GetRabbitMQHostnameAndPort(cr *apiv1alpha2.Astarte) (string, int16) {
 	if cr.Spec.RabbitMQ.Connection != nil {
 		if cr.Spec.RabbitMQ.Connection.Host != "" {
 			return cr.Spec.RabbitMQ.Connection.Host, pointy.Int16Value(cr.Spec.RabbitMQ.Connection.Port, 5672)
@@ -223,7 +237,7 @@ func GetRabbitMQHostnameAndPort(cr *apiv1alpha1.Astarte) (string, int16) {
 }
 
 // GetRabbitMQUserCredentialsSecret gets the secret holding RabbitMQ credentials in the form <secret name>, <username key>, <password key>
-func GetRabbitMQUserCredentialsSecret(cr *apiv1alpha1.Astarte) (string, string, string) {
+func GetRabbitMQUserCredentialsSecret(cr *apiv1alpha2.Astarte) (string, string, string) {
 	if cr.Spec.RabbitMQ.Connection != nil {
 		if cr.Spec.RabbitMQ.Connection.Secret != nil {
 			return cr.Spec.RabbitMQ.Connection.Secret.Name, cr.Spec.RabbitMQ.Connection.Secret.UsernameKey, cr.Spec.RabbitMQ.Connection.Secret.PasswordKey
@@ -237,13 +251,13 @@ func GetRabbitMQUserCredentialsSecret(cr *apiv1alpha1.Astarte) (string, string,
 // GetRabbitMQCredentialsFor returns the RabbitMQ host, username and password for a given CR. This information
 // can be used for connecting to RabbitMQ from the Operator or an external agent, and it should not be used for
 // any other purpose.
-func GetRabbitMQCredentialsFor(cr *apiv1alpha1.Astarte, c client.Client) (string, string, string, error) {
+func GetRabbitMQCredentialsFor(ctx context.Context, cr *apiv1alpha2.Astarte, c client.Client) (string, string, string, error) {
 	host, _ := GetRabbitMQHostnameAndPort(cr)
 	secretName, usernameKey, passwordKey := GetRabbitMQUserCredentialsSecret(cr)
 
 	// Fetch the Secret
 	secret := &v1.Secret{}
-	if err := c.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: cr.Namespace}, secret); err != nil {
+	if err := c.Get(ctx, types.NamespacedName{Name: secretName, Namespace: cr.Namespace}, secret); err != nil {
 		return "", "", "", err
 	}
 
@@ -0,0 +1,10 @@
+package webhook
+
+import (
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/webhook/astartevoyageringress"
+)
+
+func init() {
+	// AddToManagerFuncs is a list of functions to create webhooks and add them to a manager.
+	AddToManagerFuncs = append(AddToManagerFuncs, astartevoyageringress.Add)
+}
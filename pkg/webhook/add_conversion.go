@@ -0,0 +1,19 @@
+package webhook
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+)
+
+// ConversionWebhookPath is the path the Conversion Webhook for all Astarte resources is served at
+const ConversionWebhookPath = "/convert"
+
+func init() {
+	// The Conversion Webhook converts any resource implementing conversion.Convertible to its Hub version, and back.
+	AddToManagerFuncs = append(AddToManagerFuncs, addConversionWebhook)
+}
+
+func addConversionWebhook(mgr manager.Manager) error {
+	mgr.GetWebhookServer().Register(ConversionWebhookPath, &conversion.Webhook{})
+	return nil
+}
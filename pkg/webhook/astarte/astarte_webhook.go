@@ -0,0 +1,79 @@
+package astarte
+
+import (
+	"fmt"
+
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/webhook/generic"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"kmodules.xyz/webhook-runtime/admission"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// MutatingWebhookPath is the path the Astarte Defaulting Webhook is served at
+	MutatingWebhookPath = "/mutate-api-astarte-platform-org-v1alpha2-astarte"
+	// ValidatingWebhookPath is the path the Astarte Validating Webhook is served at
+	ValidatingWebhookPath = "/validate-api-astarte-platform-org-v1alpha2-astarte"
+)
+
+// Add creates the Astarte Admission Webhooks and registers them to the Manager's Webhook Server
+func Add(mgr manager.Manager) error {
+	if err := generic.Register(mgr, MutatingWebhookPath, newMutatingWebhook(mgr)); err != nil {
+		return err
+	}
+	return generic.Register(mgr, ValidatingWebhookPath, newValidatingWebhook(mgr))
+}
+
+func newMutatingWebhook(mgr manager.Manager) *generic.Webhook {
+	return generic.NewWebhook(
+		schema.GroupVersionResource{Group: "admission.astarte-platform.org", Version: "v1beta1", Resource: "astartedefaulters"},
+		"astartedefaulter",
+		apiv1alpha2.SchemeGroupVersion.WithKind("Astarte"),
+		mgr.GetScheme(),
+		admission.ResourceHandlerFuncs{
+			CreateFunc: func(obj runtime.Object) (runtime.Object, error) {
+				return defaultAstarte(obj)
+			},
+			UpdateFunc: func(oldObj, newObj runtime.Object) (runtime.Object, error) {
+				return defaultAstarte(newObj)
+			},
+		},
+	)
+}
+
+func defaultAstarte(obj runtime.Object) (runtime.Object, error) {
+	astarte, ok := obj.(*apiv1alpha2.Astarte)
+	if !ok {
+		return nil, fmt.Errorf("Expected an Astarte resource, got %T", obj)
+	}
+	defaulted := astarte.DeepCopy()
+	defaulted.Default()
+	return defaulted, nil
+}
+
+func newValidatingWebhook(mgr manager.Manager) *generic.Webhook {
+	return generic.NewWebhook(
+		schema.GroupVersionResource{Group: "admission.astarte-platform.org", Version: "v1beta1", Resource: "astartevalidators"},
+		"astartevalidator",
+		apiv1alpha2.SchemeGroupVersion.WithKind("Astarte"),
+		mgr.GetScheme(),
+		admission.ResourceHandlerFuncs{
+			CreateFunc: func(obj runtime.Object) (runtime.Object, error) {
+				astarte, ok := obj.(*apiv1alpha2.Astarte)
+				if !ok {
+					return nil, fmt.Errorf("Expected an Astarte resource, got %T", obj)
+				}
+				return nil, astarte.ValidateCreate()
+			},
+			UpdateFunc: func(oldObj, newObj runtime.Object) (runtime.Object, error) {
+				astarte, ok := newObj.(*apiv1alpha2.Astarte)
+				if !ok {
+					return nil, fmt.Errorf("Expected an Astarte resource, got %T", newObj)
+				}
+				return nil, astarte.ValidateUpdate(oldObj)
+			},
+		},
+	)
+}
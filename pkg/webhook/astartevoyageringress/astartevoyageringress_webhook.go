@@ -0,0 +1,47 @@
+package astartevoyageringress
+
+import (
+	"fmt"
+
+	apiv1alpha2 "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
+	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/webhook/generic"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"kmodules.xyz/webhook-runtime/admission"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// MutatingWebhookPath is the path the AstarteVoyagerIngress Defaulting Webhook is served at
+const MutatingWebhookPath = "/mutate-api-astarte-platform-org-v1alpha2-astartevoyageringress"
+
+// Add creates the AstarteVoyagerIngress Admission Webhooks and registers them to the Manager's Webhook Server
+func Add(mgr manager.Manager) error {
+	return generic.Register(mgr, MutatingWebhookPath, newMutatingWebhook(mgr))
+}
+
+func newMutatingWebhook(mgr manager.Manager) *generic.Webhook {
+	return generic.NewWebhook(
+		schema.GroupVersionResource{Group: "admission.astarte-platform.org", Version: "v1beta1", Resource: "astartevoyageringressdefaulters"},
+		"astartevoyageringressdefaulter",
+		apiv1alpha2.SchemeGroupVersion.WithKind("AstarteVoyagerIngress"),
+		mgr.GetScheme(),
+		admission.ResourceHandlerFuncs{
+			CreateFunc: func(obj runtime.Object) (runtime.Object, error) {
+				return defaultAstarteVoyagerIngress(obj)
+			},
+			UpdateFunc: func(oldObj, newObj runtime.Object) (runtime.Object, error) {
+				return defaultAstarteVoyagerIngress(newObj)
+			},
+		},
+	)
+}
+
+func defaultAstarteVoyagerIngress(obj runtime.Object) (runtime.Object, error) {
+	ingress, ok := obj.(*apiv1alpha2.AstarteVoyagerIngress)
+	if !ok {
+		return nil, fmt.Errorf("Expected an AstarteVoyagerIngress resource, got %T", obj)
+	}
+	defaulted := ingress.DeepCopy()
+	defaulted.Default()
+	return defaulted, nil
+}
@@ -0,0 +1,227 @@
+// Package generic provides an AdmissionHook which decodes objects through the Operator's Scheme, and the plumbing
+// needed to serve it from the Manager's Webhook Server. It mirrors kmodules' own generic webhook, which can't be
+// used here as it decodes objects through Kubernetes' legacy Scheme.
+package generic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	jp "gomodules.xyz/jsonpatch/v2"
+	"k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/client-go/rest"
+	"kmodules.xyz/webhook-runtime/admission"
+	api "kmodules.xyz/webhook-runtime/admission/v1beta1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+var log = logf.Log.WithName("webhook_generic")
+
+// Webhook is an AdmissionHook which hands objects of the target Kind to a ResourceHandler
+type Webhook struct {
+	plural   schema.GroupVersionResource
+	singular string
+
+	target  schema.GroupVersionKind
+	decoder runtime.Decoder
+	handler admission.ResourceHandler
+
+	initialized bool
+	lock        sync.RWMutex
+}
+
+var _ api.AdmissionHook = &Webhook{}
+
+// NewWebhook returns a new Webhook handling objects of the target Kind through handler
+func NewWebhook(
+	plural schema.GroupVersionResource,
+	singular string,
+	target schema.GroupVersionKind,
+	scheme *runtime.Scheme,
+	handler admission.ResourceHandler) *Webhook {
+	return &Webhook{
+		plural:   plural,
+		singular: singular,
+		target:   target,
+		decoder:  serializer.NewCodecFactory(scheme).UniversalDeserializer(),
+		handler:  handler,
+	}
+}
+
+// Resource returns the resource this Webhook is hosted on
+func (h *Webhook) Resource() (schema.GroupVersionResource, string) {
+	return h.plural, h.singular
+}
+
+// Initialize marks the Webhook as ready to admit requests
+func (h *Webhook) Initialize(config *rest.Config, stopCh <-chan struct{}) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.initialized = true
+	return nil
+}
+
+// Admit decides whether to accept the admission request, computing a JSON Patch when the ResourceHandler
+// returns a modified object
+func (h *Webhook) Admit(req *v1beta1.AdmissionRequest) *v1beta1.AdmissionResponse {
+	status := &v1beta1.AdmissionResponse{}
+
+	if h.handler == nil ||
+		(req.Operation != v1beta1.Create && req.Operation != v1beta1.Update && req.Operation != v1beta1.Delete) ||
+		len(req.SubResource) != 0 ||
+		req.Kind.Group != h.target.Group ||
+		req.Kind.Kind != h.target.Kind {
+		status.Allowed = true
+		return status
+	}
+
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	if !h.initialized {
+		return api.StatusUninitialized()
+	}
+
+	gvk := schema.GroupVersionKind{Group: req.Kind.Group, Version: req.Kind.Version, Kind: req.Kind.Kind}
+
+	switch req.Operation {
+	case v1beta1.Delete:
+		// OldObject is populated on Delete only from Kubernetes 1.15 on
+		if len(req.OldObject.Raw) == 0 {
+			break
+		}
+		obj, _, err := h.decoder.Decode(req.OldObject.Raw, &gvk, nil)
+		if err != nil {
+			return api.StatusBadRequest(err)
+		}
+		if err := h.handler.OnDelete(obj); err != nil {
+			return api.StatusForbidden(err)
+		}
+	case v1beta1.Create:
+		obj, _, err := h.decoder.Decode(req.Object.Raw, &gvk, nil)
+		if err != nil {
+			return api.StatusBadRequest(err)
+		}
+		original, err := json.Marshal(obj)
+		if err != nil {
+			return api.StatusBadRequest(err)
+		}
+
+		mod, err := h.handler.OnCreate(obj)
+		if err != nil {
+			return api.StatusForbidden(err)
+		} else if mod != nil {
+			if errStatus := setPatch(status, original, mod); errStatus != nil {
+				return errStatus
+			}
+		}
+	case v1beta1.Update:
+		obj, _, err := h.decoder.Decode(req.Object.Raw, &gvk, nil)
+		if err != nil {
+			return api.StatusBadRequest(err)
+		}
+		oldObj, _, err := h.decoder.Decode(req.OldObject.Raw, &gvk, nil)
+		if err != nil {
+			return api.StatusBadRequest(err)
+		}
+		original, err := json.Marshal(obj)
+		if err != nil {
+			return api.StatusBadRequest(err)
+		}
+
+		mod, err := h.handler.OnUpdate(oldObj, obj)
+		if err != nil {
+			return api.StatusForbidden(err)
+		} else if mod != nil {
+			if errStatus := setPatch(status, original, mod); errStatus != nil {
+				return errStatus
+			}
+		}
+	}
+
+	status.Allowed = true
+	return status
+}
+
+// setPatch sets the JSON Patch turning original into mod in status. original must be the decoded object encoded
+// again before being handed to the ResourceHandler, rather than the raw one from the request: decoding drops empty
+// values and unknown fields, which would otherwise show up as spurious remove operations.
+func setPatch(status *v1beta1.AdmissionResponse, original []byte, mod runtime.Object) *v1beta1.AdmissionResponse {
+	modified, err := json.Marshal(mod)
+	if err != nil {
+		return api.StatusBadRequest(err)
+	}
+	ops, err := jp.CreatePatch(original, modified)
+	if err != nil {
+		return api.StatusBadRequest(err)
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	patch, err := json.Marshal(ops)
+	if err != nil {
+		return api.StatusInternalServerError(err)
+	}
+	log.V(1).Info("Computed Admission patch", "Patch", string(patch))
+
+	status.Patch = patch
+	patchType := v1beta1.PatchTypeJSONPatch
+	status.PatchType = &patchType
+	return nil
+}
+
+// Register initializes hook and serves it from the Manager's Webhook Server at path
+func Register(mgr manager.Manager, path string, hook api.AdmissionHook) error {
+	if err := hook.Initialize(mgr.GetConfig(), nil); err != nil {
+		return err
+	}
+	mgr.GetWebhookServer().Register(path, &admissionHookHandler{hook: hook})
+	return nil
+}
+
+// admissionHookHandler serves an AdmissionHook over HTTP, speaking the AdmissionReview protocol
+type admissionHookHandler struct {
+	hook api.AdmissionHook
+}
+
+func (a *admissionHookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Content-Type") != "application/json" {
+		http.Error(w, fmt.Sprintf("Unsupported Content-Type %q, expected application/json", r.Header.Get("Content-Type")),
+			http.StatusUnsupportedMediaType)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	review := &v1beta1.AdmissionReview{}
+	if err := json.Unmarshal(body, review); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "AdmissionReview carries no request", http.StatusBadRequest)
+		return
+	}
+
+	response := a.hook.Admit(review.Request)
+	response.UID = review.Request.UID
+	review.Response = response
+	// Do not send the request back to the API Server
+	review.Request = nil
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		log.Error(err, "Could not write Admission response")
+	}
+}
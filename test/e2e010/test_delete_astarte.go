@@ -8,7 +8,7 @@ import (
 	framework "github.com/operator-framework/operator-sdk/pkg/test"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	operator "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha1"
+	operator "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
 	"github.com/astarte-platform/astarte-kubernetes-operator/test/utils"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
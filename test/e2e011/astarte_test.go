@@ -19,7 +19,7 @@ import (
 	"time"
 
 	"github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis"
-	operator "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha1"
+	operator "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
 
 	framework "github.com/operator-framework/operator-sdk/pkg/test"
 	"github.com/operator-framework/operator-sdk/pkg/test/e2eutil"
@@ -1,7 +1,7 @@
 package utils
 
 import (
-	operator "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha1"
+	operator "github.com/astarte-platform/astarte-kubernetes-operator/pkg/apis/api/v1alpha2"
 
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
@@ -26,7 +26,7 @@ var AstarteTestResource *operator.Astarte = &operator.Astarte{
 		},
 		VerneMQ: operator.AstarteVerneMQSpec{
 			Host: "broker.autotest.astarte-platform.org",
-			GenericClusteredResource: operator.AstarteGenericClusteredResource{
+			AstarteGenericClusteredResource: operator.AstarteGenericClusteredResource{
 				Resources: v1.ResourceRequirements{
 					Limits: v1.ResourceList{
 						v1.ResourceCPU:    *resource.NewScaledQuantity(0, resource.Milli),
@@ -40,7 +40,7 @@ var AstarteTestResource *operator.Astarte = &operator.Astarte{
 			},
 		},
 		RabbitMQ: operator.AstarteRabbitMQSpec{
-			GenericClusteredResource: operator.AstarteGenericClusteredResource{
+			AstarteGenericClusteredResource: operator.AstarteGenericClusteredResource{
 				Resources: v1.ResourceRequirements{
 					Limits: v1.ResourceList{
 						v1.ResourceCPU:    *resource.NewScaledQuantity(1000, resource.Milli),
@@ -59,7 +59,7 @@ var AstarteTestResource *operator.Astarte = &operator.Astarte{
 			Storage: &operator.AstartePersistentStorageSpec{
 				Size: resource.NewScaledQuantity(10, resource.Giga),
 			},
-			GenericClusteredResource: operator.AstarteGenericClusteredResource{
+			AstarteGenericClusteredResource: operator.AstarteGenericClusteredResource{
 				Resources: v1.ResourceRequirements{
 					Limits: v1.ResourceList{
 						v1.ResourceCPU:    *resource.NewScaledQuantity(1000, resource.Milli),